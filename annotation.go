@@ -0,0 +1,46 @@
+package jsonschema
+
+// Annotation is the value of an annotation keyword, eg. title or default,
+// collected from the schemas an instance location has passed.
+type Annotation struct {
+	Field                   string // instance location
+	Keyword                 string
+	KeywordLocation         string // location of the keyword, through any $ref
+	AbsoluteKeywordLocation string // location of the keyword in its schema resource
+	Value                   interface{}
+}
+
+func WithAnnotation(a Annotation) *Result {
+	return &Result{Annotations: []Annotation{a}}
+}
+
+func (r *Result) WithAnnotation(a Annotation) *Result {
+	if r == nil {
+		return &Result{Annotations: []Annotation{a}}
+	}
+	r.Annotations = append(r.Annotations, a)
+	return r
+}
+
+// AnnotationsOf returns annotations collected for the instance location.
+func (r *Result) AnnotationsOf(field string) []Annotation {
+	if r == nil {
+		return nil
+	}
+	var as []Annotation
+	for _, a := range r.Annotations {
+		if a.Field == field {
+			as = append(as, a)
+		}
+	}
+	return as
+}
+
+// DropAnnotations is called by a schema failed validation,
+// a failed schema produces no annotations.
+func (r *Result) DropAnnotations() *Result {
+	if r != nil {
+		r.Annotations = nil
+	}
+	return r
+}
@@ -0,0 +1,54 @@
+package jsonschema_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	schema "github.com/eachain/jsonschema"
+)
+
+func TestAnnotations(t *testing.T) {
+	sc := compile(t, `{
+		"title": "root",
+		"properties": {"a": {"description": "an a", "x-unit": "cm"}},
+		"anyOf": [{"type": "object", "title": "object"}, {"type": "string", "title": "string"}]
+	}`, "draft-07")
+
+	result := sc.Validate(parse(t, `{"a": 1}`), schema.WithAnnotations())
+	if !result.Valid() {
+		t.Fatalf("validate: %v", result.Error())
+	}
+	got := make(map[string][]string)
+	for _, a := range result.Annotations {
+		got[a.Field+" "+a.Keyword] = append(got[a.Field+" "+a.Keyword], fmt.Sprint(a.Value))
+	}
+	want := map[string][]string{
+		"# title":         {`"root"`, `"object"`},
+		"#/a description": {`"an a"`},
+		"#/a x-unit":      {`"cm"`},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("annotations: got %v, want %v", got, want)
+	}
+
+	if r := sc.Validate(parse(t, `{"a": 1}`)); r != nil && len(r.Annotations) > 0 {
+		t.Errorf("annotations collected without WithAnnotations: %v", r.Annotations)
+	}
+	if r := sc.Validate(parse(t, `{"a": 1, "b": []}`), schema.WithAnnotations()); len(r.AnnotationsOf("#/b")) > 0 {
+		t.Errorf("annotations of unknown location: %v", r.AnnotationsOf("#/b"))
+	}
+	if r := sc.Validate(parse(t, `[]`), schema.WithAnnotations()); r.Valid() || len(r.Annotations) > 0 {
+		t.Errorf("invalid instance: got %v", r)
+	}
+}
+
+func TestUnknownKeywordSchemaErrors(t *testing.T) {
+	sc, result := schema.Compile(parse(t, `{"x-sub": {"minLength": "x"}}`), "draft-07")
+	if !result.Valid() {
+		t.Fatalf("compile: %v", result.Error())
+	}
+	if sc == nil || !hasWarning(result, "should be") {
+		t.Errorf("error of schema in unknown keyword not warned: %v", result.Warning())
+	}
+}
@@ -8,16 +8,16 @@ import (
 )
 
 func AdditionalItems(ctx *schema.Context, js jsi.JSON) (val schema.Validator, result *schema.Result) {
+	allowed := false
 	if js.Type() == jsi.TypeBoolean {
-		if js.(jsi.Boolean).Value() {
-			return nil, nil
-		}
+		allowed = js.(jsi.Boolean).Value()
 	} else {
 		root := schema.GetKeyword(ctx.Draft(), schema.RootKeyword)
 		val, result = root.Compile(ctx, js)
 		if !result.Valid() {
 			return nil, result
 		}
+		allowed = val == nil
 	}
 
 	items := jsi.SiblingOf(js, "items")
@@ -32,11 +32,11 @@ func AdditionalItems(ctx *schema.Context, js jsi.JSON) (val schema.Validator, re
 			Value: js,
 			Msg:   "useless when sibling 'items' is " + msg,
 		})
-		return
+		return nil, result
 	}
 
 	max := items.(jsi.Array).Len()
-	if val == nil {
+	if val == nil && !allowed {
 		return &MaxItemsValidator{
 			Number: strconv.Itoa(max),
 			Value:  max,
@@ -54,7 +54,7 @@ func ValidateAdditionalItems(cmp schema.Compiler) schema.CompileFunc {
 
 type AdditionalItemsValidator struct {
 	ItemsCount int
-	Additional schema.Validator
+	Additional schema.Validator // nil if any additional item is allowed
 }
 
 func (ai *AdditionalItemsValidator) Validate(ctx *schema.Context, js jsi.JSON) (result *schema.Result) {
@@ -63,8 +63,19 @@ func (ai *AdditionalItemsValidator) Validate(ctx *schema.Context, js jsi.JSON) (
 	}
 
 	arr := js.(jsi.Array)
-	for i := ai.ItemsCount; i < arr.Len(); i++ {
-		result = result.Merge(ai.Additional.Validate(ctx.Array(i), arr.Index(i)))
+	if ai.Additional != nil {
+		for i := ai.ItemsCount; i < arr.Len(); i++ {
+			if rs, ok := checkCanceled(ctx, js, result); ok {
+				return rs
+			}
+			if ctx.Enough(result) {
+				break
+			}
+			result = result.Merge(ai.Additional.Validate(ctx.Array(i), arr.Index(i)))
+		}
+	}
+	if ctx.Evaluating() {
+		result = result.EvaluateItems(ctx.Field(), arr.Len())
 	}
 	return
 }
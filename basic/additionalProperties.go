@@ -9,18 +9,21 @@ import (
 
 func AdditionalProperties(ctx *schema.Context, js jsi.JSON) (val schema.Validator, result *schema.Result) {
 	var validateAdditional schema.Validator
+	var forbidden bool
 	if js.Type() == jsi.TypeBoolean {
-		if js.(jsi.Boolean).Value() {
-			return
-		}
-		validateAdditional = schema.ValidateFunc(func(ctx *schema.Context, js jsi.JSON) *schema.Result {
-			return schema.WithError(schema.Error{
-				Field: ctx.Field(),
-				Type:  js.Type(),
-				Value: js,
-				Msg:   "should NOT have additional properties",
+		if !js.(jsi.Boolean).Value() {
+			forbidden = true
+			validateAdditional = schema.ValidateFunc(func(ctx *schema.Context, js jsi.JSON) *schema.Result {
+				return schema.WithError(schema.Error{
+					Field: ctx.Field(),
+					Type:  js.Type(),
+					Value: js,
+					Msg:   "should NOT have additional properties",
+
+					Code: schema.CodeAdditionalProperties,
+				})
 			})
-		})
+		}
 	} else {
 		root := schema.GetKeyword(ctx.Draft(), schema.RootKeyword)
 		validateAdditional, result = root.Compile(ctx, js)
@@ -29,6 +32,17 @@ func AdditionalProperties(ctx *schema.Context, js jsi.JSON) (val schema.Validato
 		}
 	}
 
+	val = &AdditionalPropertiesValidator{
+		IsProperty: declaredProperties(js),
+		Additional: validateAdditional,
+		Forbidden:  forbidden,
+	}
+	return
+}
+
+// declaredProperties returns whether a key is declared by
+// 'properties' or 'patternProperties' sibling of keyword js.
+func declaredProperties(js jsi.JSON) func(key string) bool {
 	inProp := make(map[string]bool)
 	if pp := jsi.SiblingOf(js, "properties"); pp != nil && pp.Type() == jsi.TypeObject {
 		prop := pp.(jsi.Object).Iter()
@@ -49,7 +63,7 @@ func AdditionalProperties(ctx *schema.Context, js jsi.JSON) (val schema.Validato
 		}
 	}
 
-	isProperty := func(key string) bool {
+	return func(key string) bool {
 		if inProp[key] {
 			return true
 		}
@@ -60,12 +74,6 @@ func AdditionalProperties(ctx *schema.Context, js jsi.JSON) (val schema.Validato
 		}
 		return false
 	}
-
-	val = &AdditionalPropertiesValidator{
-		IsProperty: isProperty,
-		Additional: validateAdditional,
-	}
-	return
 }
 
 func ValidateAdditionalProperties(cmp schema.Compiler) schema.CompileFunc {
@@ -74,22 +82,82 @@ func ValidateAdditionalProperties(cmp schema.Compiler) schema.CompileFunc {
 
 type AdditionalPropertiesValidator struct {
 	IsProperty func(key string) bool
-	Additional schema.Validator
+	Additional schema.Validator // nil if any additional property is allowed
+	Forbidden  bool             // additionalProperties is false
+}
+
+func (ap *AdditionalPropertiesValidator) RemoveAdditional(ctx *schema.Context, js jsi.JSON) {
+	switch ctx.RemovingAdditional() {
+	case schema.RemoveAll:
+		removeAdditional(js, ap.IsProperty, nil)
+	case schema.RemoveDeclaredFalse:
+		if ap.Forbidden {
+			removeAdditional(js, ap.IsProperty, nil)
+		}
+	case schema.RemoveFailing:
+		if ap.Additional != nil {
+			removeAdditional(js, ap.IsProperty, func(key string, val jsi.JSON) bool {
+				return !ap.Additional.Validate(ctx.Tentative().Object(key), val).Valid()
+			})
+		}
+	}
 }
 
 func (ap *AdditionalPropertiesValidator) Validate(ctx *schema.Context, js jsi.JSON) (result *schema.Result) {
 	if js.Type() != jsi.TypeObject {
 		return
 	}
+	if ap.Additional == nil && !ctx.Evaluating() {
+		return
+	}
 
 	iter := js.(jsi.Object).Iter()
 	for iter.Next() {
+		if rs, ok := checkCanceled(ctx, js, result); ok {
+			return rs
+		}
+		if ctx.Enough(result) {
+			break
+		}
 		key, js := iter.Entry()
 		if ap.IsProperty(key) {
 			continue
 		}
 
-		result = result.Merge(ap.Additional.Validate(ctx.Object(key), js))
+		if ap.Additional != nil {
+			result = result.Merge(ap.Additional.Validate(ctx.Object(key), js))
+		}
+		if ctx.Evaluating() {
+			result = result.EvaluateProperty(ctx.Field(), key)
+		}
 	}
 	return
 }
+
+// Remover is implemented by validators which remove additional members
+// of the instance. RootObjectValidator applies them before validating
+// any keyword if ctx.RemovingAdditional() is set.
+type Remover interface {
+	RemoveAdditional(ctx *schema.Context, js jsi.JSON)
+}
+
+// removeAdditional removes members of js not declared, which fail
+// if fail is not nil.
+func removeAdditional(js jsi.JSON, declared func(key string) bool, fail func(key string, val jsi.JSON) bool) {
+	obj, ok := js.(jsi.MutableObject)
+	if !ok {
+		return
+	}
+
+	var keys []string
+	iter := obj.Iter()
+	for iter.Next() {
+		key, val := iter.Entry()
+		if !declared(key) && (fail == nil || fail(key, val)) {
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range keys {
+		obj.Delete(key)
+	}
+}
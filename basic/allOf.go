@@ -1,6 +1,8 @@
 package basic
 
 import (
+	"strconv"
+
 	schema "github.com/eachain/jsonschema"
 	"github.com/eachain/jsonschema/jsi"
 )
@@ -34,9 +36,10 @@ func AllOf(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result)
 		subctx := ctx.Array(i)
 		v, res := root.Compile(subctx, elt)
 		result = result.Merge(res)
-		if v != nil {
-			allOf = append(allOf, v)
+		if v == nil {
+			v = schema.ValidateFunc(placeholderValidateFunc)
 		}
+		allOf = append(allOf, v)
 	}
 	if !result.Valid() {
 		return nil, result
@@ -54,8 +57,15 @@ type AllOfValidator struct {
 }
 
 func (a *AllOfValidator) Validate(ctx *schema.Context, js jsi.JSON) (result *schema.Result) {
-	for _, v := range a.Conds {
-		result = result.Merge(v.Validate(ctx, js))
+	for i, v := range a.Conds {
+		if rs, ok := checkCanceled(ctx, js, result); ok {
+			return rs
+		}
+		if ctx.Enough(result) {
+			break
+		}
+		result = result.Merge(v.Validate(ctx.SubSchema(strconv.Itoa(i)), js))
+		js = ctx.Current(js) // replaced by the subschema
 	}
 	return
 }
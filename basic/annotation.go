@@ -0,0 +1,29 @@
+package basic
+
+import (
+	schema "github.com/eachain/jsonschema"
+	"github.com/eachain/jsonschema/jsi"
+)
+
+// AnnotationValidator never fails, it collects the value of keyword,
+// eg. title and default, as an annotation of the instance.
+type AnnotationValidator struct {
+	Value jsi.JSON
+}
+
+func (a *AnnotationValidator) Validate(ctx *schema.Context, js jsi.JSON) *schema.Result {
+	if !ctx.Annotating() {
+		return nil
+	}
+	return schema.WithAnnotation(annotationOf(ctx, a.Value))
+}
+
+func annotationOf(ctx *schema.Context, value interface{}) schema.Annotation {
+	return schema.Annotation{
+		Field:                   ctx.Field(),
+		Keyword:                 ctx.Keyword(),
+		KeywordLocation:         ctx.KeywordLocation(),
+		AbsoluteKeywordLocation: ctx.AbsoluteKeywordLocation(),
+		Value:                   value,
+	}
+}
@@ -1,6 +1,8 @@
 package basic
 
 import (
+	"strconv"
+
 	schema "github.com/eachain/jsonschema"
 	"github.com/eachain/jsonschema/jsi"
 )
@@ -34,9 +36,10 @@ func AnyOf(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result)
 		subctx := ctx.Array(i)
 		v, res := root.Compile(subctx, elt)
 		result = result.Merge(res)
-		if v != nil {
-			anyOf = append(anyOf, v)
+		if v == nil {
+			v = schema.ValidateFunc(placeholderValidateFunc)
 		}
+		anyOf = append(anyOf, v)
 	}
 	if !result.Valid() {
 		return nil, result
@@ -54,16 +57,37 @@ type AnyOfValidator struct {
 }
 
 func (a *AnyOfValidator) Validate(ctx *schema.Context, js jsi.JSON) *schema.Result {
-	for _, v := range a.Conds {
-		rs := v.Validate(ctx, js)
-		if rs.Valid() {
+	var matched bool
+	var result *schema.Result
+	var causes []schema.Error
+	for i, v := range a.Conds {
+		rs := v.Validate(ctx.Tentative().SubSchema(strconv.Itoa(i)), js)
+		if rs, ok := checkCanceled(ctx, js, result); ok {
+			return rs
+		}
+		if !rs.Valid() {
+			if !matched {
+				causes = append(causes, rs.Errors...)
+			}
+			continue
+		}
+		if !ctx.Evaluating() && !ctx.Annotating() {
 			return nil
 		}
+		// every matched schema counts for what has been evaluated and annotated
+		matched = true
+		result = result.Merge(rs)
+	}
+	if matched {
+		return result
 	}
 	return schema.WithError(schema.Error{
-		Field: ctx.Field(),
-		Type:  js.Type(),
-		Value: js,
-		Msg:   "should match some schema in anyOf",
+		Field:  ctx.Field(),
+		Type:   js.Type(),
+		Value:  js,
+		Msg:    "should match some schema in anyOf",
+		Causes: causes,
+
+		Code: schema.CodeAnyOf,
 	})
 }
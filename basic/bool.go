@@ -18,12 +18,18 @@ func RootBoolean(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Re
 		return nil, nil
 	}
 
+	loc := ctx.Location()
 	return schema.ValidateFunc(func(ctx *schema.Context, js jsi.JSON) *schema.Result {
+		ctx = ctx.EnterSchema(loc)
 		return schema.WithError(schema.Error{
 			Field: ctx.Field(),
 			Type:  js.Type(),
 			Value: js,
 			Msg:   "invalid schema",
+
+			Code:                    schema.CodeFalseSchema,
+			KeywordLocation:         ctx.KeywordLocation(),
+			AbsoluteKeywordLocation: ctx.AbsoluteKeywordLocation(),
 		})
 	}), nil
 }
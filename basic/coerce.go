@@ -0,0 +1,121 @@
+package basic
+
+import (
+	"encoding/json"
+	"math/big"
+
+	schema "github.com/eachain/jsonschema"
+	"github.com/eachain/jsonschema/jsi"
+)
+
+// Coercer is implemented by validators which convert the instance to
+// the type expected. RootObjectValidator applies them before validating
+// any keyword if ctx.Coercing().
+type Coercer interface {
+	Coerce(ctx *schema.Context, js jsi.JSON) (jsi.JSON, *schema.Result)
+}
+
+// coerceTo converts js to typ, or returns nil if it can't be converted.
+func coerceTo(typ schema.Type, js jsi.JSON) jsi.JSON {
+	switch typ {
+	case schema.TypeString:
+		switch js.Type() {
+		case jsi.TypeNumber:
+			return jsi.NewString(js.(jsi.Number).Value().String())
+		case jsi.TypeBoolean:
+			if js.(jsi.Boolean).Value() {
+				return jsi.NewString("true")
+			}
+			return jsi.NewString("false")
+		case jsi.TypeNULL:
+			return jsi.NewString("")
+		}
+
+	case schema.TypeNumber, schema.TypeInteger:
+		var n json.Number
+		switch js.Type() {
+		case jsi.TypeString:
+			s := js.(jsi.String).Value()
+			if !isNumeric(s) {
+				return nil
+			}
+			n = json.Number(s)
+		case jsi.TypeBoolean:
+			n = "0"
+			if js.(jsi.Boolean).Value() {
+				n = "1"
+			}
+		case jsi.TypeNULL:
+			n = "0"
+		default:
+			return nil
+		}
+		if typ == schema.TypeInteger && !isInteger(n) {
+			return nil
+		}
+		return jsi.NewNumber(n)
+
+	case schema.TypeBoolean:
+		switch js.Type() {
+		case jsi.TypeString:
+			switch js.(jsi.String).Value() {
+			case "true":
+				return jsi.NewBoolean(true)
+			case "false":
+				return jsi.NewBoolean(false)
+			}
+		case jsi.TypeNumber:
+			switch n := js.(jsi.Number).Value(); {
+			case numberIs(n, 0):
+				return jsi.NewBoolean(false)
+			case numberIs(n, 1):
+				return jsi.NewBoolean(true)
+			}
+		case jsi.TypeNULL:
+			return jsi.NewBoolean(false)
+		}
+
+	case schema.TypeNULL:
+		switch js.Type() {
+		case jsi.TypeString:
+			if js.(jsi.String).Value() == "" {
+				return jsi.NewNULL()
+			}
+		case jsi.TypeNumber:
+			if numberIs(js.(jsi.Number).Value(), 0) {
+				return jsi.NewNULL()
+			}
+		case jsi.TypeBoolean:
+			if !js.(jsi.Boolean).Value() {
+				return jsi.NewNULL()
+			}
+		}
+
+	case schema.TypeArray:
+		if js.Type() != jsi.TypeObject && js.Type() != jsi.TypeArray {
+			return jsi.NewArray(js)
+		}
+	}
+	return nil
+}
+
+// isNumeric reports whether s is a JSON number without spaces.
+func isNumeric(s string) bool {
+	if s == "" || s[len(s)-1] < '0' || s[len(s)-1] > '9' {
+		return false
+	}
+	if s[0] != '-' && (s[0] < '0' || s[0] > '9') {
+		return false
+	}
+	return json.Valid([]byte(s))
+}
+
+func isInteger(n json.Number) bool {
+	x, ok := new(big.Float).SetString(n.String())
+	return ok && x.IsInt()
+}
+
+func numberIs(n json.Number, v int64) bool {
+	x, ok := new(big.Float).SetString(n.String())
+	return ok && x.Cmp(new(big.Float).SetInt64(v)) == 0
+}
@@ -0,0 +1,48 @@
+package basic
+
+import (
+	"strings"
+
+	schema "github.com/eachain/jsonschema"
+	"github.com/eachain/jsonschema/jsi"
+)
+
+func Const(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
+	return &ConstValidator{Value: js}, nil
+}
+
+func ValidateConst(cmp schema.Compiler) schema.CompileFunc {
+	return func(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
+		val, result := cmp.Compile(ctx, js)
+		typs := siblingOfType(js)
+		if len(typs) > 0 && !matchOneOf(typs, js) {
+			result = result.WithWarning(schema.Error{
+				Field: ctx.Field(),
+				Type:  js.Type(),
+				Value: js,
+				Msg:   "useless for type(s): " + strings.Join(typs, ", "),
+			})
+		}
+		return val, result
+	}
+}
+
+type ConstValidator struct {
+	Value jsi.JSON
+}
+
+func (c *ConstValidator) Validate(ctx *schema.Context, js jsi.JSON) *schema.Result {
+	if jsi.Equal(c.Value, js) {
+		return nil
+	}
+
+	return schema.WithError(schema.Error{
+		Field: ctx.Field(),
+		Type:  js.Type(),
+		Value: js,
+		Msg:   "should be equal to constant",
+
+		Code:   schema.CodeConst,
+		Params: schema.Params{"const": c.Value},
+	})
+}
@@ -0,0 +1,168 @@
+package basic
+
+import (
+	"math/big"
+	"strconv"
+
+	schema "github.com/eachain/jsonschema"
+	"github.com/eachain/jsonschema/jsi"
+)
+
+func Contains(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
+	root := schema.GetKeyword(ctx.Draft(), schema.RootKeyword)
+	val, result := root.Compile(ctx, js)
+	if !result.Valid() {
+		return nil, result
+	}
+	if val == nil {
+		val = schema.ValidateFunc(placeholderValidateFunc)
+	}
+
+	return &ContainsValidator{Validator: val, Min: 1, Max: -1}, result
+}
+
+// BoundedContains compiles 'contains' with its sibling 'minContains' and
+// 'maxContains', since draft 2019-09.
+func BoundedContains(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
+	val, result := Contains(ctx, js)
+	if val == nil {
+		return nil, result
+	}
+
+	cv := val.(*ContainsValidator)
+	if min, ok := containsBound(jsi.SiblingOf(js, "minContains")); ok {
+		cv.Min = min
+	}
+	if max, ok := containsBound(jsi.SiblingOf(js, "maxContains")); ok {
+		cv.Max = max
+	}
+	return cv, result
+}
+
+// EvaluatingContains is 'contains' since draft 2020-12,
+// whose matched items count as evaluated.
+func EvaluatingContains(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
+	val, result := BoundedContains(ctx, js)
+	if val == nil {
+		return nil, result
+	}
+
+	val.(*ContainsValidator).Evaluate = true
+	return val, result
+}
+
+func containsBound(js jsi.JSON) (int, bool) {
+	if js == nil || js.Type() != jsi.TypeNumber {
+		return 0, false
+	}
+	num, ok := new(big.Float).SetString(string(js.(jsi.Number).Value()))
+	if !ok || !num.IsInt() || num.Sign() < 0 {
+		return 0, false
+	}
+	n, _ := num.Int64()
+	return int(n), true
+}
+
+func ValidateContains(cmp schema.Compiler) schema.CompileFunc {
+	return ValidateArrayType(cmp)
+}
+
+// MinContains and MaxContains take effect in BoundedContains.
+func MinContains(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
+	return nil, checkContainsBound(ctx, js)
+}
+
+func MaxContains(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
+	return nil, checkContainsBound(ctx, js)
+}
+
+func checkContainsBound(ctx *schema.Context, js jsi.JSON) *schema.Result {
+	if _, ok := containsBound(js); !ok {
+		return schema.WithError(schema.Error{
+			Field: ctx.Field(),
+			Type:  js.Type(),
+			Value: js,
+			Msg:   "should be non-negative integer",
+		})
+	}
+	if jsi.SiblingOf(js, "contains") == nil {
+		return schema.WithWarning(schema.Error{
+			Field: ctx.Field(),
+			Type:  js.Type(),
+			Value: js,
+			Msg:   "useless when sibling 'contains' not defined",
+		})
+	}
+	return nil
+}
+
+type ContainsValidator struct {
+	Validator schema.Validator
+	Min       int
+	Max       int // -1 if unlimited
+
+	// Evaluate reports matched items as evaluated, since draft 2020-12.
+	Evaluate bool
+}
+
+func (c *ContainsValidator) Validate(ctx *schema.Context, js jsi.JSON) (result *schema.Result) {
+	if js.Type() != jsi.TypeArray {
+		return nil
+	}
+
+	arr := js.(jsi.Array)
+	matched := 0
+	for i := 0; i < arr.Len(); i++ {
+		rs := c.Validator.Validate(ctx.Tentative().Array(i), arr.Index(i))
+		if rs, ok := checkCanceled(ctx, js, result); ok {
+			return rs
+		}
+
+		if !rs.Valid() {
+			continue
+		}
+		matched++
+		if ctx.Annotating() {
+			result = result.Merge(rs)
+		}
+		if c.Evaluate && ctx.Evaluating() {
+			result = result.EvaluateItem(ctx.Field(), i)
+		} else if matched >= c.Min && c.Max < 0 && !ctx.Annotating() {
+			return nil
+		}
+	}
+
+	if matched < c.Min {
+		if c.Min == 1 {
+			return result.WithError(schema.Error{
+				Field: ctx.Field(),
+				Type:  js.Type(),
+				Value: js,
+				Msg:   "should contain a valid item",
+
+				Code: schema.CodeContains,
+			})
+		}
+		return result.WithError(schema.Error{
+			Field: ctx.Field(),
+			Type:  js.Type(),
+			Value: js,
+			Msg:   "should contain at least " + strconv.Itoa(c.Min) + " valid items",
+
+			Code:   schema.CodeMinContains,
+			Params: schema.Params{"limit": c.Min, "actual": matched},
+		})
+	}
+	if c.Max >= 0 && matched > c.Max {
+		return result.WithError(schema.Error{
+			Field: ctx.Field(),
+			Type:  js.Type(),
+			Value: js,
+			Msg:   "should contain at most " + strconv.Itoa(c.Max) + " valid items",
+
+			Code:   schema.CodeMaxContains,
+			Params: schema.Params{"limit": c.Max, "actual": matched},
+		})
+	}
+	return
+}
@@ -0,0 +1,82 @@
+package basic
+
+import (
+	"mime"
+
+	schema "github.com/eachain/jsonschema"
+	"github.com/eachain/jsonschema/jsi"
+)
+
+func ContentMediaType(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
+	if js.Type() != jsi.TypeString {
+		return nil, schema.WithError(schema.Error{
+			Field: ctx.Field(),
+			Type:  js.Type(),
+			Value: js,
+			Msg:   "should be string",
+		})
+	}
+
+	if _, _, err := mime.ParseMediaType(js.(jsi.String).Value()); err != nil {
+		return nil, schema.WithWarning(schema.Error{
+			Field: ctx.Field(),
+			Type:  js.Type(),
+			Value: js,
+			Msg:   "should be media type",
+		})
+	}
+
+	return nil, nil
+}
+
+func ContentEncoding(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
+	if js.Type() != jsi.TypeString {
+		return nil, schema.WithError(schema.Error{
+			Field: ctx.Field(),
+			Type:  js.Type(),
+			Value: js,
+			Msg:   "should be string",
+		})
+	}
+
+	switch js.(jsi.String).Value() {
+	case "7bit", "8bit", "binary", "quoted-printable", "base16", "base32", "base64":
+		return nil, nil
+	}
+	return nil, schema.WithWarning(schema.Error{
+		Field: ctx.Field(),
+		Type:  js.Type(),
+		Value: js,
+		Msg:   "encoding unrecognized",
+	})
+}
+
+func ValidateContent(cmp schema.Compiler) schema.CompileFunc {
+	return func(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
+		val, result := cmp.Compile(ctx, js)
+		err := checkSiblingOfType(ctx, js, jsi.TypeString)
+		if err != nil {
+			return val, result.WithWarning(*err)
+		}
+		return val, result
+	}
+}
+
+// ContentSchema only checks the schema, it describes the decoded content
+// and is not applied to the instance.
+func ContentSchema(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
+	root := schema.GetKeyword(ctx.Draft(), schema.RootKeyword)
+	_, result := root.Compile(ctx, js)
+	if !result.Valid() {
+		return nil, result
+	}
+	if jsi.SiblingOf(js, "contentMediaType") == nil {
+		result = result.WithWarning(schema.Error{
+			Field: ctx.Field(),
+			Type:  js.Type(),
+			Value: js,
+			Msg:   "useless when sibling 'contentMediaType' not defined",
+		})
+	}
+	return nil, result
+}
@@ -8,7 +8,7 @@ import (
 )
 
 func Default(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
-	return nil, nil
+	return &AnnotationValidator{Value: js}, nil
 }
 
 func ValidateDefault(cmp schema.Compiler) schema.CompileFunc {
@@ -19,7 +19,7 @@ func ValidateDefault(cmp schema.Compiler) schema.CompileFunc {
 			if isInUnion(ctx, js) {
 				return val, result
 			}
-			return nil, result.WithWarning(schema.Error{
+			return val, result.WithWarning(schema.Error{
 				Field: ctx.Field(),
 				Type:  js.Type(),
 				Value: js,
@@ -48,3 +48,18 @@ func ValidateDefault(cmp schema.Compiler) schema.CompileFunc {
 		return val, result
 	}
 }
+
+// Defaulter is implemented by validators which fill in missing members
+// or items by defaults of their subschemas. RootObjectValidator applies
+// them before validating any keyword if ctx.Defaulting().
+type Defaulter interface {
+	ApplyDefaults(ctx *schema.Context, js jsi.JSON)
+}
+
+// defaultOf returns the default of subschema js, or nil if not any.
+func defaultOf(js jsi.JSON) jsi.JSON {
+	if js.Type() != jsi.TypeObject {
+		return nil
+	}
+	return js.(jsi.Object).Index("default")
+}
@@ -59,10 +59,17 @@ func (dv *DependenciesValidator) Validate(ctx *schema.Context, js jsi.JSON) (res
 
 	obj := js.(jsi.Object)
 	for key, val := range dv.Dependency {
+		if rs, ok := checkCanceled(ctx, js, result); ok {
+			return rs
+		}
+		if ctx.Enough(result) {
+			break
+		}
 		if obj.Index(key) == nil {
 			continue
 		}
-		result = result.Merge(val.Validate(ctx.Object(key), js))
+		subctx := ctx.SubSchema(key)
+		result = result.Merge(locate(subctx, val.Validate(subctx, js)))
 	}
 	return
 }
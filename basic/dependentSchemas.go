@@ -0,0 +1,41 @@
+package basic
+
+import (
+	schema "github.com/eachain/jsonschema"
+	"github.com/eachain/jsonschema/jsi"
+)
+
+func DependentSchemas(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
+	if js.Type() != jsi.TypeObject {
+		return nil, schema.WithError(schema.Error{
+			Field: ctx.Field(),
+			Type:  js.Type(),
+			Value: js,
+			Msg:   "should be object",
+		})
+	}
+
+	var result *schema.Result
+
+	depOf := make(map[string]schema.Validator)
+	root := schema.GetKeyword(ctx.Draft(), schema.RootKeyword)
+	iter := js.(jsi.Object).Iter()
+	for iter.Next() {
+		key, js := iter.Entry()
+		val, rs := root.Compile(ctx.Object(key), js)
+		result = result.Merge(rs)
+		if rs.Valid() && val != nil {
+			depOf[key] = val
+		}
+	}
+
+	if !result.Valid() {
+		return nil, result
+	}
+
+	return &DependenciesValidator{Dependency: depOf}, result
+}
+
+func ValidateDependentSchemas(cmp schema.Compiler) schema.CompileFunc {
+	return ValidateObjectType(cmp)
+}
@@ -15,5 +15,5 @@ func Description(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Re
 		})
 	}
 
-	return nil, nil
+	return &AnnotationValidator{Value: js}, nil
 }
@@ -0,0 +1,56 @@
+package basic
+
+import (
+	schema "github.com/eachain/jsonschema"
+	"github.com/eachain/jsonschema/jsi"
+)
+
+// DynamicAnchorValidator wraps a schema with $dynamicAnchor, or the root of
+// a schema resource, entering a resource puts the dynamic anchors defined
+// in it into the dynamic scope.
+type DynamicAnchorValidator struct {
+	Anchor    string                      // $dynamicAnchor of the schema, if any
+	Scope     map[string]schema.Validator // dynamic anchors of the resource, nil if not a resource
+	Validator schema.Validator
+}
+
+func (da *DynamicAnchorValidator) Validate(ctx *schema.Context, js jsi.JSON) *schema.Result {
+	for anchor, val := range da.Scope {
+		ctx = ctx.WithDynamicScope(anchor, val)
+	}
+	return da.Validator.Validate(ctx, js)
+}
+
+func DynamicRef(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
+	val, result := compileRef(ctx, js)
+	if !result.Valid() {
+		return nil, result
+	}
+
+	ref := val.(*ReferenceValidator)
+	ptr, _ := schema.ParsePointer(ref.Ref)
+	var anchor string
+	if len(ptr.Frag) == 1 && anchorFormat.MatchString(ptr.Frag[0]) {
+		anchor = ptr.Frag[0]
+	}
+	return &DynamicRefValidator{Anchor: anchor, Reference: ref}, result
+}
+
+type DynamicRefValidator struct {
+	Anchor    string // plain name fragment of the reference, if any
+	Reference *ReferenceValidator
+}
+
+func (dr *DynamicRefValidator) Validate(ctx *schema.Context, js jsi.JSON) *schema.Result {
+	// dynamic only if the initially resolved schema has the same dynamic anchor
+	if da, ok := dr.Reference.Validator.(*DynamicAnchorValidator); ok && dr.Anchor != "" && da.Anchor == dr.Anchor {
+		if val := ctx.DynamicScope(dr.Anchor); val != nil {
+			ctx, rs := enterRef(ctx, js, dr.Reference.Ref)
+			if rs != nil {
+				return rs
+			}
+			return val.Validate(ctx, js)
+		}
+	}
+	return dr.Reference.Validate(ctx, js)
+}
@@ -98,5 +98,8 @@ func (enum *EnumValidator) Validate(ctx *schema.Context, js jsi.JSON) *schema.Re
 		Type:  js.Type(),
 		Value: js,
 		Msg:   "should be equal to one of the allowed values",
+
+		Code:   schema.CodeEnum,
+		Params: schema.Params{"allowed": enum.Values},
 	})
 }
@@ -0,0 +1,13 @@
+package basic
+
+import (
+	schema "github.com/eachain/jsonschema"
+	"github.com/eachain/jsonschema/jsi"
+)
+
+// ExclusiveMaximum is the numeric form of exclusiveMaximum, since draft-06.
+func ExclusiveMaximum(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
+	return Compare(func(result int) bool {
+		return result < 0
+	}, "lt").Compile(ctx, js)
+}
@@ -0,0 +1,13 @@
+package basic
+
+import (
+	schema "github.com/eachain/jsonschema"
+	"github.com/eachain/jsonschema/jsi"
+)
+
+// ExclusiveMinimum is the numeric form of exclusiveMinimum, since draft-06.
+func ExclusiveMinimum(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
+	return Compare(func(result int) bool {
+		return result > 0
+	}, "gt").Compile(ctx, js)
+}
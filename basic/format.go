@@ -37,7 +37,8 @@ func GenFormat(formatOf FormatOf) schema.CompileFunc {
 		format := js.(jsi.String).Value()
 		validator := formatOf[format]
 		if validator == nil {
-			return nil, schema.WithWarning(schema.Error{
+			// unknown format is still collected as an annotation
+			return &FormatValidator{Format: format}, schema.WithWarning(schema.Error{
 				Field: ctx.Field(),
 				Type:  js.Type(),
 				Value: js,
@@ -69,11 +70,17 @@ func ValidateFormat(cmp schema.Compiler) schema.CompileFunc {
 
 type FormatValidator struct {
 	Format    string
-	Validator schema.Validator
+	Validator schema.Validator // nil if format unknown
 }
 
-func (m *FormatValidator) Validate(ctx *schema.Context, js jsi.JSON) *schema.Result {
-	return m.Validator.Validate(ctx, js)
+func (m *FormatValidator) Validate(ctx *schema.Context, js jsi.JSON) (result *schema.Result) {
+	if m.Validator != nil {
+		result = m.Validator.Validate(ctx, js)
+	}
+	if ctx.Annotating() && result.Valid() {
+		result = result.WithAnnotation(annotationOf(ctx, m.Format))
+	}
+	return
 }
 
 // formats
@@ -117,6 +124,9 @@ func DateTimeFormatValidator(ctx *schema.Context, js jsi.JSON) *schema.Result {
 			Type:  js.Type(),
 			Value: js,
 			Msg:   "should be date-time format",
+
+			Code:   schema.CodeFormat,
+			Params: schema.Params{"format": "date-time"},
 		})
 	}
 	return nil
@@ -135,6 +145,9 @@ func DateFormatValidator(ctx *schema.Context, js jsi.JSON) *schema.Result {
 			Type:  js.Type(),
 			Value: js,
 			Msg:   "should be date format",
+
+			Code:   schema.CodeFormat,
+			Params: schema.Params{"format": "date"},
 		})
 	}
 	return nil
@@ -164,6 +177,9 @@ func TimeFormatValidator(ctx *schema.Context, js jsi.JSON) *schema.Result {
 			Type:  js.Type(),
 			Value: js,
 			Msg:   "should be time format",
+
+			Code:   schema.CodeFormat,
+			Params: schema.Params{"format": "time"},
 		})
 	}
 	return nil
@@ -180,6 +196,9 @@ func EmailFormatValidator(ctx *schema.Context, js jsi.JSON) *schema.Result {
 			Type:  js.Type(),
 			Value: js,
 			Msg:   "should be email format",
+
+			Code:   schema.CodeFormat,
+			Params: schema.Params{"format": "email"},
 		})
 	}
 	return nil
@@ -198,6 +217,9 @@ func HostnameFormatValidator(ctx *schema.Context, js jsi.JSON) *schema.Result {
 			Type:  js.Type(),
 			Value: js,
 			Msg:   "should be hostname format",
+
+			Code:   schema.CodeFormat,
+			Params: schema.Params{"format": "hostname"},
 		})
 	}
 	return nil
@@ -214,6 +236,9 @@ func IPv4FormatValidator(ctx *schema.Context, js jsi.JSON) *schema.Result {
 			Type:  js.Type(),
 			Value: js,
 			Msg:   "should be ipv4 format",
+
+			Code:   schema.CodeFormat,
+			Params: schema.Params{"format": "ipv4"},
 		})
 	}
 	ip := net.ParseIP(val)
@@ -223,6 +248,9 @@ func IPv4FormatValidator(ctx *schema.Context, js jsi.JSON) *schema.Result {
 			Type:  js.Type(),
 			Value: js,
 			Msg:   "should be ipv4 format",
+
+			Code:   schema.CodeFormat,
+			Params: schema.Params{"format": "ipv4"},
 		})
 	}
 	return nil
@@ -239,6 +267,9 @@ func IPv6FormatValidator(ctx *schema.Context, js jsi.JSON) *schema.Result {
 			Type:  js.Type(),
 			Value: js,
 			Msg:   "should be ipv6 format",
+
+			Code:   schema.CodeFormat,
+			Params: schema.Params{"format": "ipv6"},
 		})
 	}
 	ip := net.ParseIP(val)
@@ -248,6 +279,9 @@ func IPv6FormatValidator(ctx *schema.Context, js jsi.JSON) *schema.Result {
 			Type:  js.Type(),
 			Value: js,
 			Msg:   "should be ipv6 format",
+
+			Code:   schema.CodeFormat,
+			Params: schema.Params{"format": "ipv6"},
 		})
 	}
 	return nil
@@ -264,6 +298,9 @@ func URIFormatValidator(ctx *schema.Context, js jsi.JSON) *schema.Result {
 			Type:  js.Type(),
 			Value: js,
 			Msg:   "should be uri format",
+
+			Code:   schema.CodeFormat,
+			Params: schema.Params{"format": "uri"},
 		})
 	}
 
@@ -274,6 +311,9 @@ func URIFormatValidator(ctx *schema.Context, js jsi.JSON) *schema.Result {
 			Type:  js.Type(),
 			Value: js,
 			Msg:   "should be uri format",
+
+			Code:   schema.CodeFormat,
+			Params: schema.Params{"format": "uri"},
 		})
 	}
 	return nil
@@ -289,7 +329,10 @@ func URIReferenceFormatValidator(ctx *schema.Context, js jsi.JSON) *schema.Resul
 			Field: ctx.Field(),
 			Type:  js.Type(),
 			Value: js,
-			Msg:   "should be uri format",
+			Msg:   "should be uri-reference format",
+
+			Code:   schema.CodeFormat,
+			Params: schema.Params{"format": "uri-reference"},
 		})
 	}
 
@@ -299,7 +342,95 @@ func URIReferenceFormatValidator(ctx *schema.Context, js jsi.JSON) *schema.Resul
 			Field: ctx.Field(),
 			Type:  js.Type(),
 			Value: js,
-			Msg:   "should be uri format",
+			Msg:   "should be uri-reference format",
+
+			Code:   schema.CodeFormat,
+			Params: schema.Params{"format": "uri-reference"},
+		})
+	}
+	return nil
+}
+
+var regexpJSONPointer = regexp.MustCompile(`^(/([^~/]|~[01])*)*$`)
+
+func JSONPointerFormatValidator(ctx *schema.Context, js jsi.JSON) *schema.Result {
+	if js.Type() != jsi.TypeString {
+		return nil
+	}
+	val := js.(jsi.String).Value()
+	if !regexpJSONPointer.MatchString(val) {
+		return schema.WithError(schema.Error{
+			Field: ctx.Field(),
+			Type:  js.Type(),
+			Value: js,
+			Msg:   "should be json-pointer format",
+
+			Code:   schema.CodeFormat,
+			Params: schema.Params{"format": "json-pointer"},
+		})
+	}
+	return nil
+}
+
+var regexpRelativeJSONPointer = regexp.MustCompile(`^(0|[1-9][0-9]*)(#|(/([^~/]|~[01])*)*)$`)
+
+func RelativeJSONPointerFormatValidator(ctx *schema.Context, js jsi.JSON) *schema.Result {
+	if js.Type() != jsi.TypeString {
+		return nil
+	}
+	val := js.(jsi.String).Value()
+	if !regexpRelativeJSONPointer.MatchString(val) {
+		return schema.WithError(schema.Error{
+			Field: ctx.Field(),
+			Type:  js.Type(),
+			Value: js,
+			Msg:   "should be relative-json-pointer format",
+
+			Code:   schema.CodeFormat,
+			Params: schema.Params{"format": "relative-json-pointer"},
+		})
+	}
+	return nil
+}
+
+var regexpUUID = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+func UUIDFormatValidator(ctx *schema.Context, js jsi.JSON) *schema.Result {
+	if js.Type() != jsi.TypeString {
+		return nil
+	}
+	val := js.(jsi.String).Value()
+	if !regexpUUID.MatchString(val) {
+		return schema.WithError(schema.Error{
+			Field: ctx.Field(),
+			Type:  js.Type(),
+			Value: js,
+			Msg:   "should be uuid format",
+
+			Code:   schema.CodeFormat,
+			Params: schema.Params{"format": "uuid"},
+		})
+	}
+	return nil
+}
+
+// ISO 8601 duration, eg. P3Y6M4DT12H30M5S or P4W.
+var regexpDuration = regexp.MustCompile(`^P(([0-9]+Y)?([0-9]+M)?([0-9]+D)?(T([0-9]+H)?([0-9]+M)?([0-9]+S)?)?|[0-9]+W)$`)
+
+func DurationFormatValidator(ctx *schema.Context, js jsi.JSON) *schema.Result {
+	if js.Type() != jsi.TypeString {
+		return nil
+	}
+	val := js.(jsi.String).Value()
+	if !regexpDuration.MatchString(val) || val == "P" || strings.HasSuffix(val, "T") {
+		return schema.WithError(schema.Error{
+			Field: ctx.Field(),
+			Type:  js.Type(),
+			Value: js,
+			Msg:   "should be duration format",
+
+			Code:   schema.CodeFormat,
+			Params: schema.Params{"format": "duration"},
 		})
 	}
 	return nil
@@ -316,7 +447,10 @@ func RegexFormatValidator(ctx *schema.Context, js jsi.JSON) *schema.Result {
 			Field: ctx.Field(),
 			Type:  js.Type(),
 			Value: js,
-			Msg:   "should be uri format",
+			Msg:   "should be regex format",
+
+			Code:   schema.CodeFormat,
+			Params: schema.Params{"format": "regex"},
 		})
 	}
 	return nil
@@ -2,6 +2,7 @@ package basic
 
 import (
 	"math/big"
+	"strconv"
 	"strings"
 
 	schema "github.com/eachain/jsonschema"
@@ -112,3 +113,28 @@ func matchOneOf(typs []jsi.Type, js jsi.JSON) bool {
 
 	return false
 }
+
+func joinInts(ns []int) string {
+	ss := make([]string, len(ns))
+	for i, n := range ns {
+		ss[i] = strconv.Itoa(n)
+	}
+	return strings.Join(ss, ", ")
+}
+
+// checkCanceled returns result with an error of CodeCanceled and true
+// if validation is canceled, the error is added only once.
+func checkCanceled(ctx *schema.Context, js jsi.JSON, result *schema.Result) (*schema.Result, bool) {
+	e := ctx.Canceled(js)
+	if e == nil {
+		return result, false
+	}
+	if result != nil {
+		for _, re := range result.Errors {
+			if re.Code == schema.CodeCanceled {
+				return result, true
+			}
+		}
+	}
+	return result.WithError(*e), true
+}
@@ -0,0 +1,95 @@
+package basic
+
+import (
+	schema "github.com/eachain/jsonschema"
+	"github.com/eachain/jsonschema/jsi"
+)
+
+// If compiles the 'if' keyword together with its sibling 'then' and 'else',
+// which only make sense as a whole.
+func If(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
+	root := schema.GetKeyword(ctx.Draft(), schema.RootKeyword)
+	cond, result := root.Compile(ctx, js)
+
+	var then, els schema.Validator
+	if sb := jsi.SiblingOf(js, "then"); sb != nil {
+		v, res := root.Compile(ctx.Parent().Object("then"), sb)
+		result = result.Merge(res)
+		then = v
+	}
+	if sb := jsi.SiblingOf(js, "else"); sb != nil {
+		v, res := root.Compile(ctx.Parent().Object("else"), sb)
+		result = result.Merge(res)
+		els = v
+	}
+	if !result.Valid() {
+		return nil, result
+	}
+	if cond == nil {
+		cond = schema.ValidateFunc(placeholderValidateFunc)
+	}
+
+	return &IfValidator{If: cond, Then: then, Else: els}, result
+}
+
+func ValidateIf(cmp schema.Compiler) schema.CompileFunc {
+	return cmp.Compile
+}
+
+// Then compiles 'then' on its own only when there is no sibling 'if',
+// otherwise it is part of If.
+func Then(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
+	return branchOfIf(ctx, js, "then")
+}
+
+// Else compiles 'else' on its own only when there is no sibling 'if',
+// otherwise it is part of If.
+func Else(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
+	return branchOfIf(ctx, js, "else")
+}
+
+func branchOfIf(ctx *schema.Context, js jsi.JSON, keyword string) (schema.Validator, *schema.Result) {
+	if jsi.SiblingOf(js, "if") != nil {
+		return nil, nil
+	}
+
+	root := schema.GetKeyword(ctx.Draft(), schema.RootKeyword)
+	_, result := root.Compile(ctx, js)
+	if !result.Valid() {
+		return nil, result
+	}
+	return nil, result.WithWarning(schema.Error{
+		Field: ctx.Field(),
+		Type:  js.Type(),
+		Value: js,
+		Msg:   "useless when sibling 'if' not defined: " + keyword,
+	})
+}
+
+type IfValidator struct {
+	If   schema.Validator
+	Then schema.Validator
+	Else schema.Validator
+}
+
+func (iv *IfValidator) Validate(ctx *schema.Context, js jsi.JSON) *schema.Result {
+	// without then and else, if counts only for its evaluated members
+	// and annotations
+	if iv.Then == nil && iv.Else == nil && !ctx.Evaluating() && !ctx.Annotating() {
+		return nil
+	}
+	rs := iv.If.Validate(ctx.Tentative(), js)
+	if rs, ok := checkCanceled(ctx, js, nil); ok {
+		return rs
+	}
+	if rs.Valid() {
+		if iv.Then != nil {
+			return rs.Merge(iv.Then.Validate(ctx.WithKeyword("then"), js))
+		}
+		return rs
+	}
+	if iv.Else != nil {
+		return iv.Else.Validate(ctx.WithKeyword("else"), js)
+	}
+	return nil
+}
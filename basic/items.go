@@ -16,14 +16,35 @@ func Items(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result)
 		return nil, result
 	}
 
-	// var prefix int
-	// if prefixItems := jsi.SiblingOf(js, "prefixItems"); prefixItems != nil {
-	// 	if prefixItems.Type() == jsi.TypeArray {
-	// 		prefix = prefixItems.(jsi.Array).Len()
-	// 	}
-	// }
-
-	return &ItemTypeValidator{Validator: val}, nil
+	return &ItemTypeValidator{Validator: val}, result
+}
+
+// PrefixedItems is 'items' since draft 2020-12, which applies to
+// the items after those of sibling 'prefixItems'.
+func PrefixedItems(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
+	if js.Type() == jsi.TypeArray {
+		return nil, schema.WithError(schema.Error{
+			Field: ctx.Field(),
+			Type:  js.Type(),
+			Value: js,
+			Msg:   "should be object or boolean, use 'prefixItems' instead",
+		})
+	}
+
+	root := schema.GetKeyword(ctx.Draft(), schema.RootKeyword)
+	val, result := root.Compile(ctx, js)
+	if !result.Valid() {
+		return nil, result
+	}
+
+	var prefix int
+	if prefixItems := jsi.SiblingOf(js, "prefixItems"); prefixItems != nil {
+		if prefixItems.Type() == jsi.TypeArray {
+			prefix = prefixItems.(jsi.Array).Len()
+		}
+	}
+
+	return &ItemTypeValidator{Prefix: prefix, Validator: val}, result
 }
 
 func ValidateItems(cmp schema.Compiler) schema.CompileFunc {
@@ -31,7 +52,8 @@ func ValidateItems(cmp schema.Compiler) schema.CompileFunc {
 }
 
 type ItemTypeValidator struct {
-	Validator schema.Validator
+	Prefix    int              // items before Prefix are skipped
+	Validator schema.Validator // nil if any item is allowed
 }
 
 func (it *ItemTypeValidator) Validate(ctx *schema.Context, js jsi.JSON) (result *schema.Result) {
@@ -40,8 +62,19 @@ func (it *ItemTypeValidator) Validate(ctx *schema.Context, js jsi.JSON) (result
 	}
 
 	arr := js.(jsi.Array)
-	for i := 0; i < arr.Len(); i++ {
-		result = result.Merge(it.Validator.Validate(ctx, arr.Index(i)))
+	if it.Validator != nil {
+		for i := it.Prefix; i < arr.Len(); i++ {
+			if rs, ok := checkCanceled(ctx, js, result); ok {
+				return rs
+			}
+			if ctx.Enough(result) {
+				break
+			}
+			result = result.Merge(it.Validator.Validate(ctx.Array(i), arr.Index(i)))
+		}
+	}
+	if ctx.Evaluating() {
+		result = result.EvaluateItems(ctx.Field(), arr.Len())
 	}
 	return
 }
@@ -56,6 +56,9 @@ func (m *MaxItemsValidator) Validate(ctx *schema.Context, js jsi.JSON) *schema.R
 			Type:  js.Type(),
 			Value: js,
 			Msg:   "array length should be lte " + m.Number,
+
+			Code:   schema.CodeMaxItems,
+			Params: schema.Params{"limit": m.Value, "actual": n},
 		})
 	}
 	return nil
@@ -57,13 +57,16 @@ func (m *MaxLengthValidator) Validate(ctx *schema.Context, js jsi.JSON) *schema.
 		return nil
 	}
 
-	val := js.(jsi.String).Value()
-	if utf8.RuneCountInString(val) > m.Value {
+	n := utf8.RuneCountInString(js.(jsi.String).Value())
+	if n > m.Value {
 		return schema.WithError(schema.Error{
 			Field: ctx.Field(),
 			Type:  js.Type(),
 			Value: js,
 			Msg:   "string length should be lte " + m.Number,
+
+			Code:   schema.CodeMaxLength,
+			Params: schema.Params{"limit": m.Value, "actual": n},
 		})
 	}
 	return nil
@@ -46,12 +46,16 @@ func (m *MaxPropertiesValidator) Validate(ctx *schema.Context, js jsi.JSON) *sch
 		return nil
 	}
 
-	if js.(jsi.Object).Len() > m.Value {
+	n := js.(jsi.Object).Len()
+	if n > m.Value {
 		return schema.WithError(schema.Error{
 			Field: ctx.Field(),
 			Type:  js.Type(),
 			Value: js,
 			Msg:   "object properties length should be lte " + strconv.Itoa(m.Value),
+
+			Code:   schema.CodeMaxProperties,
+			Params: schema.Params{"limit": m.Value, "actual": n},
 		})
 	}
 	return nil
@@ -56,6 +56,9 @@ func (m *MinItemsValidator) Validate(ctx *schema.Context, js jsi.JSON) *schema.R
 			Type:  js.Type(),
 			Value: js,
 			Msg:   "array length should be gte " + m.Number,
+
+			Code:   schema.CodeMinItems,
+			Params: schema.Params{"limit": m.Value, "actual": n},
 		})
 	}
 	return nil
@@ -46,12 +46,16 @@ func (m *MinPropertiesValidator) Validate(ctx *schema.Context, js jsi.JSON) *sch
 		return nil
 	}
 
-	if js.(jsi.Object).Len() < m.Value {
+	n := js.(jsi.Object).Len()
+	if n < m.Value {
 		return schema.WithError(schema.Error{
 			Field: ctx.Field(),
 			Type:  js.Type(),
 			Value: js,
 			Msg:   "object properties length should be gte " + strconv.Itoa(m.Value),
+
+			Code:   schema.CodeMinProperties,
+			Params: schema.Params{"limit": m.Value, "actual": n},
 		})
 	}
 	return nil
@@ -62,6 +62,8 @@ func (m *MultipleOfValidator) Validate(ctx *schema.Context, js jsi.JSON) *schema
 			Type:  js.Type(),
 			Value: js,
 			Msg:   "should be number",
+
+			Code: schema.CodeInvalidNumber,
 		})
 	}
 
@@ -71,6 +73,9 @@ func (m *MultipleOfValidator) Validate(ctx *schema.Context, js jsi.JSON) *schema
 			Type:  js.Type(),
 			Value: js,
 			Msg:   "should be multiple of " + m.Number,
+
+			Code:   schema.CodeMultipleOf,
+			Params: schema.Params{"divisor": m.Number},
 		})
 	}
 	return nil
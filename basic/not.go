@@ -11,6 +11,9 @@ func Not(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
 	if !result.Valid() {
 		return nil, result
 	}
+	if val == nil {
+		val = schema.ValidateFunc(placeholderValidateFunc)
+	}
 
 	return &NotValidator{Cond: val}, result
 }
@@ -24,7 +27,11 @@ type NotValidator struct {
 }
 
 func (a *NotValidator) Validate(ctx *schema.Context, js jsi.JSON) *schema.Result {
-	rs := a.Cond.Validate(ctx, js)
+	// annotations are dropped either way
+	rs := a.Cond.Validate(ctx.Tentative(), js)
+	if rs, ok := checkCanceled(ctx, js, nil); ok {
+		return rs
+	}
 	if !rs.Valid() {
 		return nil
 	}
@@ -33,5 +40,7 @@ func (a *NotValidator) Validate(ctx *schema.Context, js jsi.JSON) *schema.Result
 		Type:  js.Type(),
 		Value: js,
 		Msg:   "should NOT be valid",
+
+		Code: schema.CodeNot,
 	})
 }
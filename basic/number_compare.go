@@ -78,6 +78,8 @@ func (c *CompareValidator) Validate(ctx *schema.Context, js jsi.JSON) *schema.Re
 			Type:  js.Type(),
 			Value: js,
 			Msg:   "should be number",
+
+			Code: schema.CodeInvalidNumber,
 		})
 	}
 
@@ -87,7 +89,24 @@ func (c *CompareValidator) Validate(ctx *schema.Context, js jsi.JSON) *schema.Re
 			Type:  js.Type(),
 			Value: js,
 			Msg:   "should " + c.Op + " " + c.Number,
+
+			Code:   compareCode(c.Op),
+			Params: schema.Params{"op": c.Op, "limit": c.Number},
 		})
 	}
 	return nil
 }
+
+func compareCode(op string) schema.Code {
+	switch op {
+	case "lt":
+		return schema.CodeExclusiveMaximum
+	case "lte":
+		return schema.CodeMaximum
+	case "gt":
+		return schema.CodeExclusiveMinimum
+	case "gte":
+		return schema.CodeMinimum
+	}
+	return schema.CodeCompare
+}
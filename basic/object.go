@@ -16,7 +16,9 @@ func RootObject(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Res
 	}
 
 	var result *schema.Result
+	var keywords, unevaluatedKeywords []string
 	var vals []schema.Validator
+	var unevaluated []UnevaluatedValidator
 
 	obj := js.(jsi.Object)
 	iter := obj.Iter()
@@ -25,30 +27,45 @@ func RootObject(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Res
 		key, js := iter.Entry()
 		cmp := schema.GetKeyword(ctx.Draft(), key)
 		if cmp == nil {
+			// value of unknown keyword may be a schema referenced by $ref,
+			// or any value like an annotation
 			_, res := root.Compile(ctx.Object(key), js)
-			result = result.Merge(res)
-			if result.Valid() {
-				result = result.WithWarning(schema.Error{
-					Field: ctx.Field(),
-					Type:  js.Type(),
-					Value: js,
-					Msg:   "keyword not defined: " + key,
-				})
+			if js.Type() == jsi.TypeObject {
+				// an object is likely a schema, errors of it are reported
+				// as warnings, which makes the draft less preferred
+				result = result.Merge(asWarnings(res))
 			}
+			result = result.WithWarning(schema.Error{
+				Field: ctx.Field(),
+				Type:  js.Type(),
+				Value: js,
+				Msg:   "keyword not defined: " + key,
+			})
+			// unknown keywords are collected as annotations
+			keywords = append(keywords, key)
+			vals = append(vals, &AnnotationValidator{Value: js})
 			continue
 		}
 		val, res := cmp.Compile(ctx.Object(key), js)
 		result = result.Merge(res)
-		if val != nil {
+		if uv, ok := val.(UnevaluatedValidator); ok {
+			unevaluatedKeywords = append(unevaluatedKeywords, key)
+			unevaluated = append(unevaluated, uv)
+		} else if val != nil {
+			keywords = append(keywords, key)
 			vals = append(vals, val)
 		}
 	}
-	// }
 	if !result.Valid() {
 		return nil, result
 	}
 
-	return &RootObjectValidator{Validators: vals}, result
+	return &RootObjectValidator{
+		Location:    ctx.Location(),
+		Keywords:    append(keywords, unevaluatedKeywords...),
+		Validators:  vals,
+		Unevaluated: unevaluated,
+	}, result
 }
 
 func ValidateObjectType(cmp schema.Compiler) schema.CompileFunc {
@@ -62,13 +79,97 @@ func ValidateObjectType(cmp schema.Compiler) schema.CompileFunc {
 	}
 }
 
+// UnevaluatedValidator is implemented by validators which depend on
+// the properties and items evaluated by their sibling keywords.
+type UnevaluatedValidator interface {
+	schema.Validator
+	ValidateUnevaluated(ctx *schema.Context, js jsi.JSON, evaluated *schema.Evaluated) *schema.Result
+}
+
 type RootObjectValidator struct {
-	Validators []schema.Validator
+	Location    *schema.Pointer
+	Keywords    []string // keyword of Validators, followed by those of Unevaluated
+	Validators  []schema.Validator
+	Unevaluated []UnevaluatedValidator // run after Validators
 }
 
 func (obj *RootObjectValidator) Validate(ctx *schema.Context, js jsi.JSON) (result *schema.Result) {
-	for _, v := range obj.Validators {
-		result = result.Merge(v.Validate(ctx, js))
+	ctx = ctx.EnterSchema(obj.Location)
+	if ctx.Coercing() {
+		for i, v := range obj.Validators {
+			if c, ok := v.(Coercer); ok {
+				var rs *schema.Result
+				js, rs = c.Coerce(ctx.WithKeyword(obj.Keywords[i]), js)
+				result = result.Merge(rs)
+			}
+		}
+	}
+	if ctx.RemovingAdditional() != "" {
+		for i, v := range obj.Validators {
+			if r, ok := v.(Remover); ok {
+				r.RemoveAdditional(ctx.WithKeyword(obj.Keywords[i]), js)
+			}
+		}
+	}
+	if ctx.Defaulting() {
+		for i, v := range obj.Validators {
+			if d, ok := v.(Defaulter); ok {
+				d.ApplyDefaults(ctx.WithKeyword(obj.Keywords[i]), js)
+			}
+		}
+	}
+	for i, v := range obj.Validators {
+		if rs, ok := checkCanceled(ctx, js, result); ok {
+			return rs
+		}
+		if ctx.Enough(result) {
+			break
+		}
+		kwctx := ctx.WithKeyword(obj.Keywords[i])
+		result = result.Merge(locate(kwctx, v.Validate(kwctx, js)))
+		js = ctx.Current(js) // replaced by subschemas, eg. of allOf
+	}
+	for i, v := range obj.Unevaluated {
+		if rs, ok := checkCanceled(ctx, js, result); ok {
+			return rs
+		}
+		if ctx.Enough(result) {
+			break
+		}
+		kwctx := ctx.WithKeyword(obj.Keywords[len(obj.Validators)+i])
+		result = result.Merge(locate(kwctx, v.ValidateUnevaluated(kwctx, js, result.Evaluated(ctx.Field()))))
+	}
+	if ctx.Evaluating() {
+		result = result.KeepEvaluated(ctx.Field())
+	}
+	if !result.Valid() {
+		result = result.DropAnnotations()
 	}
 	return
 }
+
+// locate fills the keyword and its locations of errors reported by
+// the keyword, in case of any validator not filling them.
+func locate(ctx *schema.Context, result *schema.Result) *schema.Result {
+	if result == nil {
+		return nil
+	}
+	for i := range result.Errors {
+		if result.Errors[i].KeywordLocation == "" {
+			result.Errors[i].Keyword = ctx.Keyword()
+			result.Errors[i].KeywordLocation = ctx.KeywordLocation()
+			result.Errors[i].AbsoluteKeywordLocation = ctx.AbsoluteKeywordLocation()
+		}
+	}
+	return result
+}
+
+// asWarnings returns result with its errors turned into warnings.
+func asWarnings(result *schema.Result) *schema.Result {
+	if result.Valid() {
+		return result
+	}
+	result.Warnings = append(result.Warnings, result.Errors...)
+	result.Errors = nil
+	return result
+}
@@ -1,6 +1,8 @@
 package basic
 
 import (
+	"strconv"
+
 	schema "github.com/eachain/jsonschema"
 	"github.com/eachain/jsonschema/jsi"
 )
@@ -34,9 +36,10 @@ func OneOf(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result)
 		subctx := ctx.Array(i)
 		v, res := root.Compile(subctx, elt)
 		result = result.Merge(res)
-		if v != nil {
-			oneOf = append(oneOf, v)
+		if v == nil {
+			v = schema.ValidateFunc(placeholderValidateFunc)
 		}
+		oneOf = append(oneOf, v)
 	}
 	if !result.Valid() {
 		return nil, result
@@ -54,25 +57,41 @@ type OneOfValidator struct {
 }
 
 func (a *OneOfValidator) Validate(ctx *schema.Context, js jsi.JSON) *schema.Result {
-	matched := 0
-	for _, v := range a.Conds {
-		rs := v.Validate(ctx, js)
+	var matched []int
+	var result *schema.Result
+	var causes []schema.Error
+	for i, v := range a.Conds {
+		rs := v.Validate(ctx.Tentative().SubSchema(strconv.Itoa(i)), js)
+		if rs, ok := checkCanceled(ctx, js, result); ok {
+			return rs
+		}
 		if !rs.Valid() {
+			causes = append(causes, rs.Errors...)
 			continue
 		}
-		matched++
-		if matched > 1 {
-			break
-		}
+		matched = append(matched, i)
+		result = rs
 	}
-	if matched == 1 {
-		return nil
+	if len(matched) == 1 {
+		return result
+	}
+
+	msg := "should match exactly one schema in oneOf"
+	params := schema.Params{"matched": len(matched)}
+	if len(matched) > 1 {
+		// errors of the other branches don't tell why
+		causes = nil
+		msg += ", but matched " + joinInts(matched)
+		params["branches"] = matched
 	}
-	println("oneof matched:", ctx.Field(), matched)
 	return schema.WithError(schema.Error{
-		Field: ctx.Field(),
-		Type:  js.Type(),
-		Value: js,
-		Msg:   "should match exactly one schema in oneOf",
+		Field:  ctx.Field(),
+		Type:   js.Type(),
+		Value:  js,
+		Msg:    msg,
+		Causes: causes,
+
+		Code:   schema.CodeOneOf,
+		Params: params,
 	})
 }
@@ -58,6 +58,9 @@ func (p *PatternValidator) Validate(ctx *schema.Context, js jsi.JSON) *schema.Re
 			Type:  js.Type(),
 			Value: js,
 			Msg:   "string should match regex: " + p.Regex.String(),
+
+			Code:   schema.CodePattern,
+			Params: schema.Params{"pattern": p.Regex.String()},
 		})
 	}
 	return nil
@@ -35,7 +35,7 @@ func PatternProperties(ctx *schema.Context, js jsi.JSON) (validator schema.Valid
 
 		val, rs := root.Compile(subctx, js)
 		result = result.Merge(rs)
-		if rs.Valid() && val != nil {
+		if rs.Valid() {
 			props = append(props, PatternPropertyValidator{
 				Regex:     re,
 				Validator: val,
@@ -58,7 +58,7 @@ func ValidatePatternProperties(cmp schema.Compiler) schema.CompileFunc {
 
 type PatternPropertyValidator struct {
 	Regex     *regexp.Regexp
-	Validator schema.Validator
+	Validator schema.Validator // nil if any value is allowed
 }
 
 type PatternPropertiesValidator struct {
@@ -72,11 +72,22 @@ func (pp *PatternPropertiesValidator) Validate(ctx *schema.Context, js jsi.JSON)
 
 	iter := js.(jsi.Object).Iter()
 	for iter.Next() {
+		if rs, ok := checkCanceled(ctx, js, result); ok {
+			return rs
+		}
+		if ctx.Enough(result) {
+			break
+		}
 		key, js := iter.Entry()
 		subctx := ctx.Object(key)
 		for _, pv := range pp.Validators {
 			if pv.Regex.MatchString(key) {
-				result = result.Merge(pv.Validator.Validate(subctx, js))
+				if pv.Validator != nil {
+					result = result.Merge(pv.Validator.Validate(subctx.SubSchema(pv.Regex.String()), js))
+				}
+				if ctx.Evaluating() {
+					result = result.EvaluateProperty(ctx.Field(), key)
+				}
 			}
 		}
 	}
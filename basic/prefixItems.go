@@ -1,6 +1,8 @@
 package basic
 
 import (
+	"strconv"
+
 	schema "github.com/eachain/jsonschema"
 	"github.com/eachain/jsonschema/jsi"
 )
@@ -17,6 +19,7 @@ func PrefixItems(ctx *schema.Context, js jsi.JSON) (val schema.Validator, result
 
 	arr := js.(jsi.Array)
 	vs := make([]schema.Validator, arr.Len())
+	var defaults []jsi.JSON
 	root := schema.GetKeyword(ctx.Draft(), schema.RootKeyword)
 	for i := 0; i < arr.Len(); i++ {
 		v, res := root.Compile(ctx.Array(i), arr.Index(i))
@@ -24,12 +27,18 @@ func PrefixItems(ctx *schema.Context, js jsi.JSON) (val schema.Validator, result
 		if v != nil {
 			vs[i] = v
 		}
+		if def := defaultOf(arr.Index(i)); def != nil {
+			if defaults == nil {
+				defaults = make([]jsi.JSON, arr.Len())
+			}
+			defaults[i] = def
+		}
 	}
 	if !result.Valid() {
 		return
 	}
 
-	val = &PrefixItemsValidator{Items: vs}
+	val = &PrefixItemsValidator{Items: vs, Defaults: defaults}
 	return
 }
 
@@ -38,7 +47,20 @@ func ValidatePrefixItems(cmp schema.Compiler) schema.CompileFunc {
 }
 
 type PrefixItemsValidator struct {
-	Items []schema.Validator
+	Items    []schema.Validator
+	Defaults []jsi.JSON // default of each item, nil if not any
+}
+
+// ApplyDefaults appends defaults of items after the last one, until
+// an item without default, so no gap is left in the array.
+func (pi *PrefixItemsValidator) ApplyDefaults(ctx *schema.Context, js jsi.JSON) {
+	arr, ok := js.(jsi.MutableArray)
+	if !ok {
+		return
+	}
+	for i := arr.Len(); i < len(pi.Defaults) && pi.Defaults[i] != nil; i++ {
+		arr.Append(pi.Defaults[i])
+	}
 }
 
 func (pi *PrefixItemsValidator) Validate(ctx *schema.Context, js jsi.JSON) (result *schema.Result) {
@@ -53,9 +75,18 @@ func (pi *PrefixItemsValidator) Validate(ctx *schema.Context, js jsi.JSON) (resu
 	}
 
 	for i := 0; i < n; i++ {
+		if rs, ok := checkCanceled(ctx, js, result); ok {
+			return rs
+		}
+		if ctx.Enough(result) {
+			break
+		}
 		if pi.Items[i] != nil {
-			result = result.Merge(pi.Items[i].Validate(ctx, arr.Index(i)))
+			result = result.Merge(pi.Items[i].Validate(ctx.Array(i).SubSchema(strconv.Itoa(i)), arr.Index(i)))
 		}
 	}
+	if ctx.Evaluating() {
+		result = result.EvaluateItems(ctx.Field(), n)
+	}
 	return
 }
@@ -16,6 +16,7 @@ func Properties(ctx *schema.Context, js jsi.JSON) (validator schema.Validator, r
 	}
 
 	property := make(map[string]schema.Validator)
+	var defaults []PropertyDefault
 
 	root := schema.GetKeyword(ctx.Draft(), schema.RootKeyword)
 	iter := js.(jsi.Object).Iter()
@@ -23,8 +24,9 @@ func Properties(ctx *schema.Context, js jsi.JSON) (validator schema.Validator, r
 		key, js := iter.Entry()
 		val, rs := root.Compile(ctx.Object(key), js)
 		result = result.Merge(rs)
-		if val != nil {
-			property[key] = val
+		property[key] = val
+		if def := defaultOf(js); def != nil {
+			defaults = append(defaults, PropertyDefault{Key: key, Value: def})
 		}
 	}
 
@@ -32,7 +34,11 @@ func Properties(ctx *schema.Context, js jsi.JSON) (validator schema.Validator, r
 		return
 	}
 
-	validator = &PropertiesValidator{Prop: property}
+	pv := &PropertiesValidator{Prop: property, Defaults: defaults}
+	if jsi.SiblingOf(js, "additionalProperties") == nil {
+		pv.IsProperty = declaredProperties(js)
+	}
+	validator = pv
 	return
 }
 
@@ -41,7 +47,30 @@ func ValidateProperties(cmp schema.Compiler) schema.CompileFunc {
 }
 
 type PropertiesValidator struct {
-	Prop map[string]schema.Validator
+	Prop     map[string]schema.Validator // nil validator if any value is allowed
+	Defaults []PropertyDefault           // in order of properties
+
+	// IsProperty removes undeclared members as schema.RemoveAll,
+	// nil if sibling additionalProperties does it.
+	IsProperty func(key string) bool
+}
+
+// PropertyDefault is the default of a property.
+type PropertyDefault struct {
+	Key   string
+	Value jsi.JSON
+}
+
+func (p *PropertiesValidator) ApplyDefaults(ctx *schema.Context, js jsi.JSON) {
+	obj, ok := js.(jsi.MutableObject)
+	if !ok {
+		return
+	}
+	for _, def := range p.Defaults {
+		if obj.Index(def.Key) == nil {
+			obj.Set(def.Key, def.Value)
+		}
+	}
 }
 
 func (p *PropertiesValidator) Validate(ctx *schema.Context, js jsi.JSON) (result *schema.Result) {
@@ -51,11 +80,28 @@ func (p *PropertiesValidator) Validate(ctx *schema.Context, js jsi.JSON) (result
 
 	iter := js.(jsi.Object).Iter()
 	for iter.Next() {
+		if rs, ok := checkCanceled(ctx, js, result); ok {
+			return rs
+		}
+		if ctx.Enough(result) {
+			break
+		}
 		key, js := iter.Entry()
-		if val := p.Prop[key]; val != nil {
-			result = result.Merge(val.Validate(ctx.Object(key), js))
+		if val, ok := p.Prop[key]; ok {
+			if val != nil {
+				result = result.Merge(val.Validate(ctx.Object(key).SubSchema(key), js))
+			}
+			if ctx.Evaluating() {
+				result = result.EvaluateProperty(ctx.Field(), key)
+			}
 		}
 	}
 
 	return
 }
+
+func (p *PropertiesValidator) RemoveAdditional(ctx *schema.Context, js jsi.JSON) {
+	if p.IsProperty != nil && ctx.RemovingAdditional() == schema.RemoveAll {
+		removeAdditional(js, p.IsProperty, nil)
+	}
+}
@@ -0,0 +1,46 @@
+package basic
+
+import (
+	schema "github.com/eachain/jsonschema"
+	"github.com/eachain/jsonschema/jsi"
+)
+
+func PropertyNames(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
+	root := schema.GetKeyword(ctx.Draft(), schema.RootKeyword)
+	val, result := root.Compile(ctx, js)
+	if !result.Valid() {
+		return nil, result
+	}
+	if val == nil {
+		return nil, result
+	}
+
+	return &PropertyNamesValidator{Validator: val}, result
+}
+
+func ValidatePropertyNames(cmp schema.Compiler) schema.CompileFunc {
+	return ValidateObjectType(cmp)
+}
+
+type PropertyNamesValidator struct {
+	Validator schema.Validator
+}
+
+func (pn *PropertyNamesValidator) Validate(ctx *schema.Context, js jsi.JSON) (result *schema.Result) {
+	if js.Type() != jsi.TypeObject {
+		return
+	}
+
+	iter := js.(jsi.Object).Iter()
+	for iter.Next() {
+		if rs, ok := checkCanceled(ctx, js, result); ok {
+			return rs
+		}
+		if ctx.Enough(result) {
+			break
+		}
+		key, _ := iter.Entry()
+		result = result.Merge(pn.Validator.Validate(ctx.Object(key), jsi.NewString(key)))
+	}
+	return
+}
@@ -3,6 +3,7 @@ package basic
 import (
 	"fmt"
 	"regexp"
+	"strings"
 
 	schema "github.com/eachain/jsonschema"
 	"github.com/eachain/jsonschema/jsi"
@@ -11,8 +12,13 @@ import (
 type PointerKeywords struct {
 	Id       string
 	Anchor   string
-	Ref      string
+	Ref      string // exclusive reference, any sibling keywords are ignored
 	Immunity []string
+
+	// RecursiveAnchor marks a schema which $recursiveRef may resolve to, since draft 2019-09.
+	RecursiveAnchor string
+	// DynamicAnchor names a schema which $dynamicRef may resolve to, since draft 2020-12.
+	DynamicAnchor string
 }
 
 func RegisterPointer(draft string, pk PointerKeywords) {
@@ -40,6 +46,18 @@ func RegisterPointer(draft string, pk PointerKeywords) {
 			schema.RegisterKeyword(draft, pk.Anchor, schema.CompileFunc(placeholderCompileFunc))
 		}
 	}
+	if pk.RecursiveAnchor != "" {
+		anchor := schema.GetKeyword(draft, pk.RecursiveAnchor)
+		if anchor == nil {
+			schema.RegisterKeyword(draft, pk.RecursiveAnchor, schema.CompileFunc(RecursiveAnchor))
+		}
+	}
+	if pk.DynamicAnchor != "" {
+		anchor := schema.GetKeyword(draft, pk.DynamicAnchor)
+		if anchor == nil {
+			schema.RegisterKeyword(draft, pk.DynamicAnchor, schema.CompileFunc(placeholderCompileFunc))
+		}
+	}
 }
 
 func placeholderCompileFunc(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
@@ -50,6 +68,10 @@ func placeholderValidateFunc(ctx *schema.Context, js jsi.JSON) *schema.Result {
 	return nil
 }
 
+func Ref(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
+	return compileRef(ctx, js)
+}
+
 type ReferenceValidator struct {
 	Id        string
 	Ref       string
@@ -63,10 +85,21 @@ func (ref *ReferenceValidator) Validate(ctx *schema.Context, js jsi.JSON) (resul
 			Type:  js.Type(),
 			Value: js,
 			Msg:   "can't resolve reference " + ref.Ref + " from id " + ref.Id,
+
+			Code:   schema.CodeUnresolvedRef,
+			Params: schema.Params{"ref": ref.Ref, "id": ref.Id},
 		})
 		return
 	}
 
+	if rs, ok := checkCanceled(ctx, js, nil); ok {
+		return rs
+	}
+	ctx, result = enterRef(ctx, js, ref.Ref)
+	if result != nil {
+		return
+	}
+
 	defer func() {
 		if r := recover(); r != nil {
 			result = result.WithError(schema.Error{
@@ -74,6 +107,9 @@ func (ref *ReferenceValidator) Validate(ctx *schema.Context, js jsi.JSON) (resul
 				Type:  js.Type(),
 				Value: js,
 				Msg:   fmt.Sprint(r),
+
+				Code:   schema.CodeReferenceFailed,
+				Params: schema.Params{"reason": fmt.Sprint(r)},
 			})
 		}
 	}()
@@ -81,6 +117,24 @@ func (ref *ReferenceValidator) Validate(ctx *schema.Context, js jsi.JSON) (resul
 	return
 }
 
+// enterRef enters the reference being applied to js, or returns an error
+// of CodeRefCycle if it has been entered at the same instance location.
+func enterRef(ctx *schema.Context, js jsi.JSON, ref string) (*schema.Context, *schema.Result) {
+	sc, ok := ctx.EnterRef()
+	if ok {
+		return sc, nil
+	}
+	return ctx, schema.WithError(schema.Error{
+		Field: ctx.Field(),
+		Type:  js.Type(),
+		Value: js,
+		Msg:   "reference " + ref + " is applied to the same instance recursively",
+
+		Code:   schema.CodeRefCycle,
+		Params: schema.Params{"ref": ref},
+	})
+}
+
 func genCmpRoot(root schema.Compiler, pk PointerKeywords) schema.CompileFunc {
 	return func(ctx *schema.Context, js jsi.JSON) (validator schema.Validator, result *schema.Result) {
 		if js.Parent() == nil {
@@ -88,6 +142,7 @@ func genCmpRoot(root schema.Compiler, pk PointerKeywords) schema.CompileFunc {
 		}
 
 		defer func() {
+			ctx.SetSchema(js)
 			if validator != nil {
 				ctx.Impl(validator)
 			} else {
@@ -107,6 +162,13 @@ func genCmpRoot(root schema.Compiler, pk PointerKeywords) schema.CompileFunc {
 			ref := obj.Index(pk.Ref)
 			if ref != nil {
 				validator, result = cmpRef(ctx.Object(pk.Ref), ref, pk.Ref, pk.Immunity)
+				if validator != nil {
+					validator = &RootObjectValidator{
+						Location:   ctx.Location(),
+						Keywords:   []string{pk.Ref},
+						Validators: []schema.Validator{validator},
+					}
+				}
 				return
 			}
 		}
@@ -125,8 +187,43 @@ func genCmpRoot(root schema.Compiler, pk PointerKeywords) schema.CompileFunc {
 			}
 		}
 
+		var dynamicAnchor string
+		if pk.DynamicAnchor != "" {
+			anchor := obj.Index(pk.DynamicAnchor)
+			if anchor != nil {
+				res := cmpAnchor(ctx.Object(pk.DynamicAnchor), anchor)
+				result = result.Merge(res)
+				if res.Valid() {
+					dynamicAnchor = anchor.(jsi.String).Value()
+					ctx.SetDynamicAnchor(dynamicAnchor)
+				}
+			}
+		}
+
 		validator, res := root.Compile(ctx, js)
 		result = result.Merge(res)
+
+		if pk.RecursiveAnchor != "" && validator != nil {
+			anchor := obj.Index(pk.RecursiveAnchor)
+			if anchor != nil && anchor.Type() == jsi.TypeBoolean && anchor.(jsi.Boolean).Value() {
+				validator = &RecursiveAnchorValidator{Validator: validator}
+			}
+		}
+
+		if pk.DynamicAnchor != "" && validator != nil {
+			var scope map[string]schema.Validator
+			if js.Parent() == nil || (pk.Id != "" && obj.Index(pk.Id) != nil) {
+				scope = make(map[string]schema.Validator)
+				ctx.PushDynamicAnchors(scope)
+			}
+			if dynamicAnchor != "" || scope != nil {
+				validator = &DynamicAnchorValidator{
+					Anchor:    dynamicAnchor,
+					Scope:     scope,
+					Validator: validator,
+				}
+			}
+		}
 		return
 	}
 }
@@ -153,6 +250,12 @@ func cmpRef(ctx *schema.Context, js jsi.JSON, keyword string, immunity []string)
 		}
 	}
 
+	validator, res := compileRef(ctx, js)
+	result = result.Merge(res)
+	return
+}
+
+func compileRef(ctx *schema.Context, js jsi.JSON) (validator schema.Validator, result *schema.Result) {
 	if js.Type() != jsi.TypeString {
 		result = result.WithError(schema.Error{
 			Field: ctx.Field(),
@@ -206,6 +309,12 @@ func cmpId(ctx *schema.Context, js jsi.JSON) *schema.Result {
 		})
 	}
 	var result *schema.Result
+	if id.Scheme == "" && id.Host == "" && id.Path == "" &&
+		len(id.Frag) == 1 && anchorFormat.MatchString(id.Frag[0]) {
+		// "$id": "#foo" defines a plain name fragment before draft 2019-09
+		ctx.Parent().SetAnchor(id.Frag[0])
+		return nil
+	}
 	if len(id.Frag) > 0 {
 		result = result.WithWarning(schema.Error{
 			Field: ctx.Field(),
@@ -219,7 +328,7 @@ func cmpId(ctx *schema.Context, js jsi.JSON) *schema.Result {
 	return result
 }
 
-var anchorFormat = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9-_:.]*`)
+var anchorFormat = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9-_:.]*$`)
 
 func cmpAnchor(ctx *schema.Context, js jsi.JSON) *schema.Result {
 	if js.Type() != jsi.TypeString {
@@ -251,14 +360,69 @@ func checkRef(ctx *schema.Context) (result *schema.Result) {
 			result = result.Merge(loadSchemaFromURI(ref.Context, ref.JSON, ref.Ref))
 		}
 	}
+	return result.Merge(checkRefCycles(ctx))
+}
+
+// checkRefCycles reports cycles of $ref which never go into a member or
+// an item of the instance, validating against any of them recurses
+// infinitely. Cycles across schema documents are left to validation.
+func checkRefCycles(ctx *schema.Context) (result *schema.Result) {
+	for _, cycle := range ctx.RefCycles("$ref", appliedInPlace) {
+		locs := make([]string, 0, len(cycle)+1)
+		for _, ref := range cycle {
+			locs = append(locs, ref.Field())
+		}
+		locs = append(locs, locs[0])
+		var ref string
+		if s, ok := cycle[0].JSON.(jsi.String); ok {
+			ref = s.Value()
+		}
+		result = result.WithError(schema.Error{
+			Field: cycle[0].Field(),
+			Type:  cycle[0].JSON.Type(),
+			Value: cycle[0].JSON,
+			Msg:   "reference cycle applies to the same instance: " + strings.Join(locs, " -> "),
+
+			Code:    schema.CodeRefCycle,
+			Params:  schema.Params{"ref": ref},
+			Keyword: "$ref",
+		})
+	}
 	return
 }
 
+// appliedInPlace reports whether a schema applies its subschema at keys
+// to its own instance, not to a member or an item of it.
+func appliedInPlace(keys []string) bool {
+	switch len(keys) {
+	case 1:
+		switch keys[0] {
+		case "not", "if", "then", "else":
+			return true
+		}
+	case 2:
+		switch keys[0] {
+		case "allOf", "anyOf", "oneOf", "dependentSchemas", "dependencies":
+			return true
+		}
+	}
+	return false
+}
+
 func loadSchemaFromURI(ctx *schema.Context, js jsi.JSON, ref *schema.Pointer) *schema.Result {
 	u := ref.URL()
 	u.Fragment = ""
+	if u.String() == "" {
+		// a fragment of the document without uri, there is nothing to load
+		return schema.WithError(schema.Error{
+			Field: ctx.Field(),
+			Type:  js.Type(),
+			Value: js,
+			Msg:   "can't resolve reference " + ref.String(),
+		})
+	}
 
-	subjs, err := jsi.NewURLParser(u).Parse()
+	subjs, err := ctx.Load(u.String())
 	if err != nil {
 		return schema.WithError(schema.Error{
 			Field: ctx.Field(),
@@ -0,0 +1,27 @@
+package basic
+
+import (
+	schema "github.com/eachain/jsonschema"
+	"github.com/eachain/jsonschema/jsi"
+)
+
+func ReadOnly(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
+	if js.Type() != jsi.TypeBoolean {
+		return nil, schema.WithError(schema.Error{
+			Field: ctx.Field(),
+			Type:  js.Type(),
+			Value: js,
+			Msg:   "should be boolean",
+		})
+	}
+
+	return &AnnotationValidator{Value: js}, nil
+}
+
+func WriteOnly(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
+	return ReadOnly(ctx, js)
+}
+
+func Deprecated(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
+	return ReadOnly(ctx, js)
+}
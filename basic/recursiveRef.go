@@ -0,0 +1,63 @@
+package basic
+
+import (
+	schema "github.com/eachain/jsonschema"
+	"github.com/eachain/jsonschema/jsi"
+)
+
+func RecursiveAnchor(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
+	if js.Type() != jsi.TypeBoolean {
+		return nil, schema.WithError(schema.Error{
+			Field: ctx.Field(),
+			Type:  js.Type(),
+			Value: js,
+			Msg:   "should be boolean",
+		})
+	}
+
+	return nil, nil
+}
+
+// RecursiveAnchorValidator wraps a schema with "$recursiveAnchor": true,
+// entering it puts the schema into the dynamic scope.
+type RecursiveAnchorValidator struct {
+	Validator schema.Validator
+}
+
+func (ra *RecursiveAnchorValidator) Validate(ctx *schema.Context, js jsi.JSON) *schema.Result {
+	return ra.Validator.Validate(ctx.WithDynamicScope("", ra.Validator), js)
+}
+
+func RecursiveRef(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
+	if js.Type() == jsi.TypeString && js.(jsi.String).Value() != "#" {
+		return nil, schema.WithError(schema.Error{
+			Field: ctx.Field(),
+			Type:  js.Type(),
+			Value: js,
+			Msg:   "should be \"#\"",
+		})
+	}
+
+	val, result := compileRef(ctx, js)
+	if !result.Valid() {
+		return nil, result
+	}
+	return &RecursiveRefValidator{Reference: val.(*ReferenceValidator)}, result
+}
+
+type RecursiveRefValidator struct {
+	Reference *ReferenceValidator
+}
+
+func (rr *RecursiveRefValidator) Validate(ctx *schema.Context, js jsi.JSON) *schema.Result {
+	if _, ok := rr.Reference.Validator.(*RecursiveAnchorValidator); ok {
+		if val := ctx.DynamicScope(""); val != nil {
+			ctx, rs := enterRef(ctx, js, rr.Reference.Ref)
+			if rs != nil {
+				return rs
+			}
+			return val.Validate(ctx, js)
+		}
+	}
+	return rr.Reference.Validate(ctx, js)
+}
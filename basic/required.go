@@ -79,6 +79,9 @@ func (rv *RequiredValidator) Validate(ctx *schema.Context, js jsi.JSON) *schema.
 			Type:  js.Type(),
 			Value: js,
 			Msg:   "object properties required keys: " + strings.Join(required, ", "),
+
+			Code:   schema.CodeRequired,
+			Params: schema.Params{"missing": required},
 		})
 	}
 	return nil
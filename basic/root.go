@@ -0,0 +1,23 @@
+package basic
+
+import (
+	schema "github.com/eachain/jsonschema"
+	"github.com/eachain/jsonschema/jsi"
+)
+
+// Root compiles a schema which may be either an object or a boolean,
+// as allowed since draft-06.
+func Root(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
+	if js.Type() == jsi.TypeBoolean {
+		return RootBoolean(ctx, js)
+	}
+	if js.Type() != jsi.TypeObject {
+		return nil, schema.WithError(schema.Error{
+			Field: ctx.Field(),
+			Type:  js.Type(),
+			Value: js,
+			Msg:   "should be object or boolean",
+		})
+	}
+	return RootObject(ctx, js)
+}
@@ -15,7 +15,8 @@ func Schema(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result)
 		})
 	}
 
-	switch schema := js.(jsi.String).Value(); schema {
+	var draft string
+	switch uri := js.(jsi.String).Value(); uri {
 	default:
 		return nil, schema.WithError(schema.Error{
 			Field: ctx.Field(),
@@ -24,15 +25,26 @@ func Schema(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result)
 			Msg:   "schema unrecognized",
 		})
 	case "http://json-schema.org/draft-04/schema", "http://json-schema.org/draft-04/schema#":
-		ctx.UseDraft("draft-04")
+		draft = "draft-04"
 	case "http://json-schema.org/draft-06/schema", "http://json-schema.org/draft-06/schema#":
-		ctx.UseDraft("draft-06")
+		draft = "draft-06"
 	case "http://json-schema.org/draft-07/schema", "http://json-schema.org/draft-07/schema#":
-		ctx.UseDraft("draft-07")
-	case "https://json-schema.org/draft/2019-09/schema":
-		ctx.UseDraft("draft-201909")
-	case "https://json-schema.org/draft/2020-12/schema":
-		ctx.UseDraft("draft-202012")
+		draft = "draft-07"
+	case "https://json-schema.org/draft/2019-09/schema", "https://json-schema.org/draft/2019-09/schema#":
+		draft = "draft-201909"
+	case "https://json-schema.org/draft/2020-12/schema", "https://json-schema.org/draft/2020-12/schema#":
+		draft = "draft-202012"
+	}
+
+	// Compile uses the declared draft if it's one of the drafts given,
+	// otherwise a mismatch is warned.
+	if draft != ctx.Draft() {
+		return nil, schema.WithWarning(schema.Error{
+			Field: ctx.Field(),
+			Type:  js.Type(),
+			Value: js,
+			Msg:   "schema declares " + draft + ", compiled as " + ctx.Draft(),
+		})
 	}
 
 	return nil, nil
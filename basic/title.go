@@ -15,5 +15,5 @@ func Title(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result)
 		})
 	}
 
-	return nil, nil
+	return &AnnotationValidator{Value: js}, nil
 }
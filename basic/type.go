@@ -2,7 +2,6 @@ package basic
 
 import (
 	"fmt"
-	"math/big"
 	"strings"
 
 	schema "github.com/eachain/jsonschema"
@@ -100,26 +99,52 @@ type TypeValidator struct {
 }
 
 func (tv *TypeValidator) Validate(ctx *schema.Context, js jsi.JSON) *schema.Result {
+	if tv.match(js) {
+		return nil
+	}
+
+	return schema.WithError(schema.Error{
+		Field: ctx.Field(),
+		Type:  js.Type(),
+		Value: js,
+		Msg:   "should be one of the allowed types",
+
+		Code:   schema.CodeType,
+		Params: schema.Params{"expected": tv.Types, "actual": js.Type()},
+	})
+}
+
+func (tv *TypeValidator) match(js jsi.JSON) bool {
 	var hasInt bool
 	for _, typ := range tv.Types {
 		if typ == schema.TypeInteger {
 			hasInt = true
 		}
 		if js.Type() == typ {
-			return nil
+			return true
 		}
 	}
 	if hasInt && js.Type() == jsi.TypeNumber {
-		x, ok := new(big.Float).SetString(js.(jsi.Number).Value().String())
-		if ok && x.IsInt() {
-			return nil
-		}
+		return isInteger(js.(jsi.Number).Value())
 	}
+	return false
+}
 
-	return schema.WithError(schema.Error{
-		Field: ctx.Field(),
-		Type:  js.Type(),
-		Value: js,
-		Msg:   "should be one of the allowed types",
-	})
+// Coerce replaces js by the value converted to the first of Types
+// it can be converted to, if js is none of Types.
+func (tv *TypeValidator) Coerce(ctx *schema.Context, js jsi.JSON) (jsi.JSON, *schema.Result) {
+	if tv.match(js) {
+		return js, nil
+	}
+	for _, typ := range tv.Types {
+		val := coerceTo(typ, js)
+		if val == nil {
+			continue
+		}
+		if val = ctx.Replace(js, val); val == nil {
+			return js, nil
+		}
+		return val, schema.WithCoercion(schema.Coercion{Field: ctx.Field(), From: js, To: val})
+	}
+	return js, nil
 }
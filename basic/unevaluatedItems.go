@@ -0,0 +1,68 @@
+package basic
+
+import (
+	schema "github.com/eachain/jsonschema"
+	"github.com/eachain/jsonschema/jsi"
+)
+
+func UnevaluatedItems(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
+	var val schema.Validator
+	var result *schema.Result
+	if js.Type() == jsi.TypeBoolean {
+		if !js.(jsi.Boolean).Value() {
+			val = schema.ValidateFunc(func(ctx *schema.Context, js jsi.JSON) *schema.Result {
+				return schema.WithError(schema.Error{
+					Field: ctx.Field(),
+					Type:  js.Type(),
+					Value: js,
+					Msg:   "should NOT have unevaluated items",
+
+					Code: schema.CodeUnevaluatedItems,
+				})
+			})
+		}
+	} else {
+		root := schema.GetKeyword(ctx.Draft(), schema.RootKeyword)
+		val, result = root.Compile(ctx, js)
+		if !result.Valid() {
+			return nil, result
+		}
+	}
+
+	ctx.TrackEvaluated()
+	return &UnevaluatedItemsValidator{Unevaluated: val}, result
+}
+
+func ValidateUnevaluatedItems(cmp schema.Compiler) schema.CompileFunc {
+	return ValidateArrayType(cmp)
+}
+
+type UnevaluatedItemsValidator struct {
+	Unevaluated schema.Validator // nil if any unevaluated item is allowed
+}
+
+func (ui *UnevaluatedItemsValidator) Validate(ctx *schema.Context, js jsi.JSON) *schema.Result {
+	return ui.ValidateUnevaluated(ctx, js, nil)
+}
+
+func (ui *UnevaluatedItemsValidator) ValidateUnevaluated(ctx *schema.Context, js jsi.JSON, evaluated *schema.Evaluated) (result *schema.Result) {
+	if js.Type() != jsi.TypeArray {
+		return
+	}
+
+	arr := js.(jsi.Array)
+	if ui.Unevaluated != nil {
+		for i := 0; i < arr.Len(); i++ {
+			if rs, ok := checkCanceled(ctx, js, result); ok {
+				return rs
+			}
+			if ctx.Enough(result) {
+				break
+			}
+			if !evaluated.Item(i) {
+				result = result.Merge(ui.Unevaluated.Validate(ctx.Array(i), arr.Index(i)))
+			}
+		}
+	}
+	return result.EvaluateItems(ctx.Field(), arr.Len())
+}
@@ -0,0 +1,71 @@
+package basic
+
+import (
+	schema "github.com/eachain/jsonschema"
+	"github.com/eachain/jsonschema/jsi"
+)
+
+func UnevaluatedProperties(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
+	var val schema.Validator
+	var result *schema.Result
+	if js.Type() == jsi.TypeBoolean {
+		if !js.(jsi.Boolean).Value() {
+			val = schema.ValidateFunc(func(ctx *schema.Context, js jsi.JSON) *schema.Result {
+				return schema.WithError(schema.Error{
+					Field: ctx.Field(),
+					Type:  js.Type(),
+					Value: js,
+					Msg:   "should NOT have unevaluated properties",
+
+					Code: schema.CodeUnevaluatedProperties,
+				})
+			})
+		}
+	} else {
+		root := schema.GetKeyword(ctx.Draft(), schema.RootKeyword)
+		val, result = root.Compile(ctx, js)
+		if !result.Valid() {
+			return nil, result
+		}
+	}
+
+	ctx.TrackEvaluated()
+	return &UnevaluatedPropertiesValidator{Unevaluated: val}, result
+}
+
+func ValidateUnevaluatedProperties(cmp schema.Compiler) schema.CompileFunc {
+	return ValidateObjectType(cmp)
+}
+
+type UnevaluatedPropertiesValidator struct {
+	Unevaluated schema.Validator // nil if any unevaluated property is allowed
+}
+
+func (up *UnevaluatedPropertiesValidator) Validate(ctx *schema.Context, js jsi.JSON) *schema.Result {
+	return up.ValidateUnevaluated(ctx, js, nil)
+}
+
+func (up *UnevaluatedPropertiesValidator) ValidateUnevaluated(ctx *schema.Context, js jsi.JSON, evaluated *schema.Evaluated) (result *schema.Result) {
+	if js.Type() != jsi.TypeObject {
+		return
+	}
+
+	iter := js.(jsi.Object).Iter()
+	for iter.Next() {
+		if rs, ok := checkCanceled(ctx, js, result); ok {
+			return rs
+		}
+		if ctx.Enough(result) {
+			break
+		}
+		key, js := iter.Entry()
+		if evaluated.Property(key) {
+			continue
+		}
+		if up.Unevaluated != nil {
+			result = result.Merge(up.Unevaluated.Validate(ctx.Object(key), js))
+		}
+		result = result.EvaluateProperty(ctx.Field(), key)
+	}
+	return
+}
@@ -36,6 +36,9 @@ func (*UniqueItemsValidator) Validate(ctx *schema.Context, js jsi.JSON) (result
 
 	arr := js.(jsi.Array)
 	for i := 1; i < arr.Len(); i++ {
+		if rs, ok := checkCanceled(ctx, js, result); ok {
+			return rs
+		}
 		for j := 0; j < i; j++ {
 			if jsi.Equal(arr.Index(j), arr.Index(i)) {
 				result = result.WithError(schema.Error{
@@ -43,7 +46,13 @@ func (*UniqueItemsValidator) Validate(ctx *schema.Context, js jsi.JSON) (result
 					Type:  js.Type(),
 					Value: js,
 					Msg:   fmt.Sprintf("should NOT have duplicate items (items ## %v and %v are identical)", i, j),
+
+					Code:   schema.CodeUniqueItems,
+					Params: schema.Params{"i": i, "j": j},
 				})
+				if ctx.Enough(result) {
+					return
+				}
 			}
 		}
 	}
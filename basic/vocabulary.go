@@ -0,0 +1,64 @@
+package basic
+
+import (
+	schema "github.com/eachain/jsonschema"
+	"github.com/eachain/jsonschema/jsi"
+)
+
+// VocabularyOf maps vocabulary uri to the keywords it defines,
+// it tells which vocabularies are supported, see GenVocabulary.
+type VocabularyOf map[string][]string
+
+// GenVocabulary compiles "$vocabulary" of a meta-schema. It only checks
+// vocabularies required (true) are in vocabularyOf, and warns of optional
+// ones which are not. Vocabularies are not enforced: all keywords
+// registered for the draft apply, even those of a vocabulary the
+// meta-schema leaves out.
+func GenVocabulary(vocabularyOf VocabularyOf) schema.CompileFunc {
+	return func(ctx *schema.Context, js jsi.JSON) (schema.Validator, *schema.Result) {
+		if js.Type() != jsi.TypeObject {
+			return nil, schema.WithError(schema.Error{
+				Field: ctx.Field(),
+				Type:  js.Type(),
+				Value: js,
+				Msg:   "should be object",
+			})
+		}
+
+		var result *schema.Result
+		iter := js.(jsi.Object).Iter()
+		for iter.Next() {
+			uri, js := iter.Entry()
+			subctx := ctx.Object(uri)
+			if js.Type() != jsi.TypeBoolean {
+				result = result.WithError(schema.Error{
+					Field: subctx.Field(),
+					Type:  js.Type(),
+					Value: js,
+					Msg:   "should be boolean",
+				})
+				continue
+			}
+			if _, ok := vocabularyOf[uri]; ok {
+				continue
+			}
+			if js.(jsi.Boolean).Value() {
+				result = result.WithError(schema.Error{
+					Field: subctx.Field(),
+					Type:  js.Type(),
+					Value: js,
+					Msg:   "vocabulary required but not supported: " + uri,
+				})
+			} else {
+				result = result.WithWarning(schema.Error{
+					Field: subctx.Field(),
+					Type:  js.Type(),
+					Value: js,
+					Msg:   "vocabulary not supported: " + uri,
+				})
+			}
+		}
+
+		return nil, result
+	}
+}
@@ -0,0 +1,110 @@
+package jsonschema
+
+import "strings"
+
+// BestMatch returns the most relevant error, or nil if valid.
+//
+// Of errors at the shallowest instance location, errors of anyOf and oneOf
+// are the least relevant. If the error found has causes, the best of them
+// is returned instead, from the branch which fits the instance most: not
+// failing 'type' of the instance, nor 'const' or 'enum' of its members
+// like a discriminator, going deepest into it, with fewest errors.
+func (r *Result) BestMatch() *Error {
+	if r.Valid() {
+		return nil
+	}
+	return bestMatch(r.Errors)
+}
+
+func bestMatch(errs []Error) *Error {
+	best := &errs[0]
+	for i := 1; i < len(errs); i++ {
+		if moreRelevant(&errs[i], best) {
+			best = &errs[i]
+		}
+	}
+	if len(best.Causes) == 0 {
+		return best
+	}
+	return bestMatch(bestBranch(best))
+}
+
+func moreRelevant(e, than *Error) bool {
+	if d, t := fieldDepth(e.Field), fieldDepth(than.Field); d != t {
+		return d < t
+	}
+	return !isWeakError(e) && isWeakError(than)
+}
+
+func isWeakError(e *Error) bool {
+	return e.Code == CodeAnyOf || e.Code == CodeOneOf
+}
+
+func fieldDepth(field string) int {
+	return strings.Count(field, "/")
+}
+
+// bestBranch groups causes of e by branch and returns those of the best.
+func bestBranch(e *Error) []Error {
+	prefix := e.KeywordLocation + "/"
+	var names []string
+	branches := make(map[string][]Error)
+	for _, c := range e.Causes {
+		name := strings.TrimPrefix(c.KeywordLocation, prefix)
+		if name == c.KeywordLocation {
+			name = ""
+		} else if i := strings.IndexByte(name, '/'); i >= 0 {
+			name = name[:i]
+		}
+		if _, ok := branches[name]; !ok {
+			names = append(names, name)
+		}
+		branches[name] = append(branches[name], c)
+	}
+
+	type score struct {
+		typeFailed  bool
+		constFailed bool
+		depth       int
+		count       int
+	}
+	depth := fieldDepth(e.Field)
+	scoreOf := func(errs []Error) (s score) {
+		s.count = len(errs)
+		for _, c := range errs {
+			if c.Code == CodeType && c.Field == e.Field {
+				s.typeFailed = true
+			}
+			if (c.Code == CodeConst || c.Code == CodeEnum) && fieldDepth(c.Field) <= depth+1 {
+				s.constFailed = true
+			}
+			if d := fieldDepth(c.Field); d > s.depth {
+				s.depth = d
+			}
+		}
+		return
+	}
+
+	best := names[0]
+	bs := scoreOf(branches[best])
+	for _, name := range names[1:] {
+		s := scoreOf(branches[name])
+		switch {
+		case s.typeFailed != bs.typeFailed:
+			if !s.typeFailed {
+				best, bs = name, s
+			}
+		case s.constFailed != bs.constFailed:
+			if !s.constFailed {
+				best, bs = name, s
+			}
+		case s.depth != bs.depth:
+			if s.depth > bs.depth {
+				best, bs = name, s
+			}
+		case s.count < bs.count:
+			best, bs = name, s
+		}
+	}
+	return branches[best]
+}
@@ -0,0 +1,78 @@
+package jsonschema_test
+
+import (
+	"reflect"
+	"testing"
+
+	schema "github.com/eachain/jsonschema"
+)
+
+func TestCauses(t *testing.T) {
+	sc := compile(t, `{"oneOf": [{"type": "integer"}, {"minimum": 2}, {"maximum": 0}]}`, "draft-07")
+
+	result := sc.Validate(parse(t, `1.5`))
+	if len(result.Errors) != 1 || result.Errors[0].Code != schema.CodeOneOf {
+		t.Fatalf("errors: %v", result.Error())
+	}
+	var locations []string
+	for _, c := range result.Errors[0].Causes {
+		locations = append(locations, c.KeywordLocation)
+	}
+	want := []string{"#/oneOf/0/type", "#/oneOf/1/minimum", "#/oneOf/2/maximum"}
+	if !reflect.DeepEqual(locations, want) {
+		t.Errorf("causes: got %v, want %v", locations, want)
+	}
+
+	// more than one matched has no causes
+	result = sc.Validate(parse(t, `3`))
+	if len(result.Errors) != 1 || len(result.Errors[0].Causes) > 0 {
+		t.Fatalf("errors: %v", result.Errors)
+	}
+	if p := result.Errors[0].Params; p["matched"] != 2 || !reflect.DeepEqual(p["branches"], []int{0, 1}) {
+		t.Errorf("params: %v", p)
+	}
+}
+
+func TestBestMatch(t *testing.T) {
+	tests := []struct {
+		schema, instance string
+		location         string
+	}{
+		// branches failing type of the instance are the least relevant
+		{`{"anyOf": [{"type": "string"}, {"type": "integer", "minimum": 5}]}`, `1`, "#/anyOf/1/minimum"},
+		// so are those failing a discriminator
+		{`{"oneOf": [
+			{"properties": {"kind": {"const": "a"}, "x": {"type": "string"}}},
+			{"properties": {"kind": {"const": "b"}, "y": {"type": "integer"}}}
+		]}`, `{"kind": "b", "x": 1, "y": "s"}`, "#/oneOf/1/properties/y/type"},
+		// then those going deepest into the instance
+		{`{"anyOf": [
+			{"required": ["b"]},
+			{"properties": {"a": {"properties": {"c": {"type": "string"}}}}}
+		]}`, `{"a": {"c": 1}}`, "#/anyOf/1/properties/a/properties/c/type"},
+		// then those with fewest errors
+		{`{"anyOf": [{"minimum": 5, "multipleOf": 2}, {"maximum": 0}]}`, `1`, "#/anyOf/1/maximum"},
+		// errors at the shallowest location are the most relevant
+		{`{"required": ["b"], "properties": {"a": {"minLength": 2}}}`, `{"a": "x"}`, "#/required"},
+		// of which anyOf and oneOf are the least
+		{`{"anyOf": [{"type": "string"}], "minimum": 2}`, `1`, "#/minimum"},
+		// nested causes are searched
+		{`{"anyOf": [{"type": "string"}, {"oneOf": [{"type": "array"}, {"minimum": 5}]}]}`, `1`, "#/anyOf/1/oneOf/1/minimum"},
+	}
+	for _, tt := range tests {
+		sc := compile(t, tt.schema, "draft-07")
+		best := sc.Validate(parse(t, tt.instance)).BestMatch()
+		if best == nil {
+			t.Errorf("%v with %v: no error", tt.schema, tt.instance)
+			continue
+		}
+		if best.KeywordLocation != tt.location {
+			t.Errorf("%v with %v: got %v, want %v", tt.schema, tt.instance, best.KeywordLocation, tt.location)
+		}
+	}
+
+	sc := compile(t, `{"type": "integer"}`, "draft-07")
+	if best := sc.Validate(parse(t, `1`)).BestMatch(); best != nil {
+		t.Errorf("valid instance: %v", best)
+	}
+}
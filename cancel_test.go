@@ -0,0 +1,76 @@
+package jsonschema_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	schema "github.com/eachain/jsonschema"
+)
+
+// countdown is a context canceled after Err is called n times.
+type countdown struct {
+	context.Context
+	n int
+}
+
+func (c *countdown) Err() error {
+	if c.n <= 0 {
+		return context.Canceled
+	}
+	c.n--
+	return nil
+}
+
+// canceledErrors returns the number of errors of CodeCanceled.
+func canceledErrors(result *schema.Result) (n int) {
+	for _, e := range result.Errors {
+		if e.Code == schema.CodeCanceled {
+			n++
+		}
+	}
+	return
+}
+
+func TestValidateContext(t *testing.T) {
+	sc := compile(t, `{"items": {"type": "integer"}}`, "draft-07")
+	doc := parse(t, `[1, 2, "a", 4, "b"]`)
+
+	result, err := sc.ValidateContext(context.Background(), doc)
+	if err != nil || len(result.Errors) != 2 {
+		t.Errorf("not canceled: got %v, %v", result.Error(), err)
+	}
+
+	c, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err = sc.ValidateContext(c, doc)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v", err)
+	}
+	if result.Valid() || canceledErrors(result) != 1 {
+		t.Errorf("canceled: %v", result.Error())
+	}
+	if e := result.Errors[0]; e.Code != schema.CodeCanceled || !strings.Contains(e.Msg, "canceled") {
+		t.Errorf("canceled: %+v", e)
+	}
+
+	// validation stops in the middle of a loop
+	long := parse(t, "["+strings.Repeat(`"a", `, 99)+`"a"]`)
+	result, err = sc.ValidateContext(&countdown{Context: context.Background(), n: 20}, long)
+	if !errors.Is(err, context.Canceled) || canceledErrors(result) != 1 {
+		t.Fatalf("canceled in the middle: got %v, %v", result.Error(), err)
+	}
+	if n := len(result.Errors) - 1; n == 0 || n >= 100 {
+		t.Errorf("got %d errors before canceled", n)
+	}
+}
+
+func TestValidateContextReadOnly(t *testing.T) {
+	sc := compile(t, `{"type": "integer"}`, "draft-07")
+	// options changing the instance are dropped
+	result, err := sc.ValidateContext(context.Background(), parse(t, `"42"`), schema.WithCoerceTypes())
+	if err != nil || result.Valid() {
+		t.Errorf("got %v, %v", result.Error(), err)
+	}
+}
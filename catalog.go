@@ -0,0 +1,191 @@
+package jsonschema
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Catalog renders the message of an error, eg. in a locale,
+// it returns "" if the error is unknown to it.
+type Catalog interface {
+	Message(e Error) string
+}
+
+type CatalogFunc func(e Error) string
+
+func (fn CatalogFunc) Message(e Error) string {
+	return fn(e)
+}
+
+// MessageCatalog renders messages by templates of codes,
+// "{name}" in a template is replaced with param name of the error.
+type MessageCatalog map[Code]string
+
+func (mc MessageCatalog) Message(e Error) string {
+	tmpl, ok := mc[e.Code]
+	if !ok {
+		return ""
+	}
+	return renderMessage(tmpl, e.Params)
+}
+
+// KeywordCatalog renders messages of errors by catalog of their keyword,
+// eg. to override messages of 'pattern'.
+type KeywordCatalog map[string]Catalog
+
+func (kc KeywordCatalog) Message(e Error) string {
+	if c := kc[e.Keyword]; c != nil {
+		return c.Message(e)
+	}
+	return ""
+}
+
+// Catalogs renders messages by the first catalog knowing the error.
+type Catalogs []Catalog
+
+func (cs Catalogs) Message(e Error) string {
+	for _, c := range cs {
+		if msg := c.Message(e); msg != "" {
+			return msg
+		}
+	}
+	return ""
+}
+
+// Localize renders Msg of errors by catalog,
+// errors unknown to catalog are kept as they are.
+func (r *Result) Localize(c Catalog) *Result {
+	if r == nil {
+		return nil
+	}
+	localize(r.Errors, c)
+	return r
+}
+
+func localize(errs []Error, c Catalog) {
+	for i := range errs {
+		localize(errs[i].Causes, c)
+		if errs[i].Code == "" {
+			continue
+		}
+		if msg := c.Message(errs[i]); msg != "" {
+			errs[i].Msg = msg
+		}
+	}
+}
+
+func renderMessage(tmpl string, params Params) string {
+	b := new(strings.Builder)
+	for {
+		i := strings.IndexByte(tmpl, '{')
+		if i < 0 {
+			break
+		}
+		j := strings.IndexByte(tmpl[i:], '}')
+		if j < 0 {
+			break
+		}
+		val, ok := params[tmpl[i+1:i+j]]
+		if !ok {
+			b.WriteString(tmpl[:i+j+1])
+		} else {
+			b.WriteString(tmpl[:i])
+			b.WriteString(formatParam(val))
+		}
+		tmpl = tmpl[i+j+1:]
+	}
+	b.WriteString(tmpl)
+	return b.String()
+}
+
+func formatParam(val interface{}) string {
+	switch v := val.(type) {
+	case []string:
+		return strings.Join(v, ", ")
+	case []int:
+		ss := make([]string, len(v))
+		for i, n := range v {
+			ss[i] = strconv.Itoa(n)
+		}
+		return strings.Join(ss, ", ")
+	}
+	return fmt.Sprint(val)
+}
+
+// EnglishCatalog renders the same messages as validators in package basic,
+// except that of CodeOneOf doesn't list the branches matched, which are
+// in param "branches" if more than one.
+var EnglishCatalog = MessageCatalog{
+	CodeFalseSchema:           "invalid schema",
+	CodeType:                  "should be one of the allowed types",
+	CodeConst:                 "should be equal to constant",
+	CodeEnum:                  "should be equal to one of the allowed values",
+	CodeInvalidNumber:         "should be number",
+	CodeMultipleOf:            "should be multiple of {divisor}",
+	CodeMaximum:               "should lte {limit}",
+	CodeExclusiveMaximum:      "should lt {limit}",
+	CodeMinimum:               "should gte {limit}",
+	CodeExclusiveMinimum:      "should gt {limit}",
+	CodeCompare:               "should {op} {limit}",
+	CodeMaxLength:             "string length should be lte {limit}",
+	CodeMinLength:             "string length should be gte {limit}",
+	CodePattern:               "string should match regex: {pattern}",
+	CodeFormat:                "should be {format} format",
+	CodeMaxItems:              "array length should be lte {limit}",
+	CodeMinItems:              "array length should be gte {limit}",
+	CodeUniqueItems:           "should NOT have duplicate items (items ## {i} and {j} are identical)",
+	CodeContains:              "should contain a valid item",
+	CodeMaxContains:           "should contain at most {limit} valid items",
+	CodeMinContains:           "should contain at least {limit} valid items",
+	CodeUnevaluatedItems:      "should NOT have unevaluated items",
+	CodeMaxProperties:         "object properties length should be lte {limit}",
+	CodeMinProperties:         "object properties length should be gte {limit}",
+	CodeRequired:              "object properties required keys: {missing}",
+	CodeAdditionalProperties:  "should NOT have additional properties",
+	CodeUnevaluatedProperties: "should NOT have unevaluated properties",
+	CodeAnyOf:                 "should match some schema in anyOf",
+	CodeOneOf:                 "should match exactly one schema in oneOf",
+	CodeNot:                   "should NOT be valid",
+	CodeUnresolvedRef:         "can't resolve reference {ref} from id {id}",
+	CodeReferenceFailed:       "{reason}",
+	CodeRefCycle:              "reference {ref} is applied to the same instance recursively",
+	CodeCanceled:              "validation canceled: {reason}",
+}
+
+var ChineseCatalog = MessageCatalog{
+	CodeFalseSchema:           "不允许任何值",
+	CodeType:                  "类型应为 {expected} 之一，实际为 {actual}",
+	CodeConst:                 "应等于常量 {const}",
+	CodeEnum:                  "应为允许的值之一",
+	CodeInvalidNumber:         "应为数字",
+	CodeMultipleOf:            "应为 {divisor} 的倍数",
+	CodeMaximum:               "应小于或等于 {limit}",
+	CodeExclusiveMaximum:      "应小于 {limit}",
+	CodeMinimum:               "应大于或等于 {limit}",
+	CodeExclusiveMinimum:      "应大于 {limit}",
+	CodeCompare:               "应满足 {op} {limit}",
+	CodeMaxLength:             "字符串长度应不超过 {limit}，实际为 {actual}",
+	CodeMinLength:             "字符串长度应不少于 {limit}，实际为 {actual}",
+	CodePattern:               "字符串应匹配正则表达式：{pattern}",
+	CodeFormat:                "应为 {format} 格式",
+	CodeMaxItems:              "数组长度应不超过 {limit}，实际为 {actual}",
+	CodeMinItems:              "数组长度应不少于 {limit}，实际为 {actual}",
+	CodeUniqueItems:           "数组元素不应重复（第 {j} 项与第 {i} 项相同）",
+	CodeContains:              "应至少包含一个有效元素",
+	CodeMaxContains:           "应至多包含 {limit} 个有效元素，实际为 {actual}",
+	CodeMinContains:           "应至少包含 {limit} 个有效元素，实际为 {actual}",
+	CodeUnevaluatedItems:      "不应包含未经校验的元素",
+	CodeMaxProperties:         "对象属性个数应不超过 {limit}，实际为 {actual}",
+	CodeMinProperties:         "对象属性个数应不少于 {limit}，实际为 {actual}",
+	CodeRequired:              "缺少必需的属性：{missing}",
+	CodeAdditionalProperties:  "不应包含额外的属性",
+	CodeUnevaluatedProperties: "不应包含未经校验的属性",
+	CodeAnyOf:                 "应至少匹配 anyOf 中的一个 schema",
+	CodeOneOf:                 "应恰好匹配 oneOf 中的一个 schema",
+	CodeNot:                   "不应通过校验",
+	CodeUnresolvedRef:         "无法从 {id} 解析引用 {ref}",
+	CodeReferenceFailed:       "{reason}",
+	CodeRefCycle:              "引用 {ref} 被递归应用于同一实例",
+	CodeCanceled:              "校验已取消: {reason}",
+}
@@ -0,0 +1,122 @@
+package jsonschema_test
+
+import (
+	"reflect"
+	"testing"
+
+	schema "github.com/eachain/jsonschema"
+)
+
+func TestErrorCodes(t *testing.T) {
+	sc := compile(t, errorSchema, "draft-07")
+	result := sc.Validate(parse(t, errorInstance))
+
+	var codes []schema.Code
+	for _, e := range result.Errors {
+		codes = append(codes, e.Code)
+	}
+	want := []schema.Code{schema.CodeRequired, schema.CodeMaxLength, schema.CodeMinimum,
+		schema.CodeAnyOf, schema.CodeUniqueItems, schema.CodeEnum}
+	if !reflect.DeepEqual(codes, want) {
+		t.Fatalf("codes: got %v, want %v", codes, want)
+	}
+
+	params := []schema.Params{
+		{"missing": []string{"x"}},
+		{"limit": 2, "actual": 3},
+		{"limit": "1", "op": "gte"},
+	}
+	for i, p := range params {
+		if !reflect.DeepEqual(result.Errors[i].Params, p) {
+			t.Errorf("params of %v: got %#v, want %#v", result.Errors[i].Code, result.Errors[i].Params, p)
+		}
+	}
+	if causes := result.Errors[3].Causes; len(causes) != 2 || causes[0].Code != schema.CodeType {
+		t.Errorf("causes of anyOf: %v", causes)
+	}
+}
+
+func TestEnglishCatalog(t *testing.T) {
+	// messages rendered are those of validators
+	sc := compile(t, errorSchema, "draft-07")
+	result := sc.Validate(parse(t, errorInstance))
+	want := result.Error()
+	if got := result.Localize(schema.EnglishCatalog).Error(); got != want {
+		t.Errorf("got  %v\nwant %v", got, want)
+	}
+}
+
+func TestFormatMessages(t *testing.T) {
+	tests := []struct {
+		format, value string
+		english       string
+		chinese       string
+	}{
+		{"uri", `"a"`, "should be uri format", "应为 uri 格式"},
+		{"uri-reference", `"a\\b"`, "should be uri-reference format", "应为 uri-reference 格式"},
+		{"regex", `"("`, "should be regex format", "应为 regex 格式"},
+	}
+	for _, tt := range tests {
+		sc := compile(t, `{"format": "`+tt.format+`"}`, "draft-07")
+		result := sc.Validate(parse(t, tt.value))
+		if len(result.Errors) != 1 {
+			t.Fatalf("%v: %v", tt.format, result.Error())
+		}
+		e := result.Errors[0]
+		if e.Msg != tt.english || e.Params["format"] != tt.format {
+			t.Errorf("%v: got %q with %v", tt.format, e.Msg, e.Params)
+		}
+		if msg := schema.EnglishCatalog.Message(e); msg != tt.english {
+			t.Errorf("%v: english %q", tt.format, msg)
+		}
+		if msg := schema.ChineseCatalog.Message(e); msg != tt.chinese {
+			t.Errorf("%v: chinese %q", tt.format, msg)
+		}
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	sc := compile(t, errorSchema, "draft-07")
+	result := sc.Validate(parse(t, errorInstance), schema.WithCatalog(schema.Catalogs{
+		schema.KeywordCatalog{"maxLength": schema.MessageCatalog{schema.CodeMaxLength: "too long: {actual} > {limit}"}},
+		schema.CatalogFunc(func(e schema.Error) string {
+			if e.Code == schema.CodeRequired {
+				return "missing {missing}" // returned as is
+			}
+			return ""
+		}),
+		schema.ChineseCatalog,
+	}))
+	want := []string{
+		"missing {missing}",
+		"too long: 3 > 2",
+		"应大于或等于 1",
+		"应至少匹配 anyOf 中的一个 schema",
+		"数组元素不应重复（第 0 项与第 1 项相同）",
+		"应为允许的值之一",
+	}
+	for i, e := range result.Errors {
+		if e.Msg != want[i] {
+			t.Errorf("%v: got %q, want %q", e.Code, e.Msg, want[i])
+		}
+	}
+	if msg := result.Errors[3].Causes[0].Msg; msg != "类型应为 string 之一，实际为 number" {
+		t.Errorf("cause not localized: %q", msg)
+	}
+
+	// errors unknown to catalogs are kept
+	unknown := schema.MessageCatalog{}
+	result = sc.Validate(parse(t, errorInstance))
+	msg := result.Errors[0].Msg
+	if result.Localize(unknown); result.Errors[0].Msg != msg {
+		t.Errorf("message of unknown error changed: %q", result.Errors[0].Msg)
+	}
+}
+
+func TestRenderMessage(t *testing.T) {
+	c := schema.MessageCatalog{schema.CodeOneOf: "{matched} of {branches}, {unknown} {"}
+	got := c.Message(schema.Error{Code: schema.CodeOneOf, Params: schema.Params{"matched": 2, "branches": []int{0, 3}}})
+	if want := "2 of 0, 3, {unknown} {"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
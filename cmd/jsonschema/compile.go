@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	schema "github.com/eachain/jsonschema"
+)
+
+// schemaReport is the json output of compile and lint.
+type schemaReport struct {
+	Schema   string      `json:"schema"`
+	Draft    string      `json:"draft,omitempty"`
+	Valid    bool        `json:"valid"`
+	Errors   []errorJSON `json:"errors,omitempty"`
+	Warnings []errorJSON `json:"warnings,omitempty"`
+}
+
+// compile reports errors and warnings of schemas,
+// only errors fail it.
+func compile(args []string) int {
+	return checkSchemas("compile", args, false)
+}
+
+// lint reports errors and warnings of schemas, including errors
+// validated by meta-schemas, only errors fail it unless -strict.
+func lint(args []string) int {
+	return checkSchemas("lint", args, true)
+}
+
+func checkSchemas(name string, args []string, meta bool) int {
+	var opts options
+	var strict bool
+	fs := newFlagSet(name, "schema.json ...")
+	opts.register(fs)
+	fs.BoolVar(&strict, "strict", false, "fail on warnings too")
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+	if err := opts.check(); err != nil {
+		fmt.Fprintf(os.Stderr, "jsonschema %v: %v\n", name, err)
+		return exitError
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitError
+	}
+
+	code := exitOK
+	var reports []schemaReport
+	for _, file := range fs.Args() {
+		uri, err := schemaURI(file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "jsonschema %v: %v: %v\n", name, file, err)
+			return exitError
+		}
+
+		reg := opts.registry()
+		reg.ValidateSchema = meta
+		sc, result := reg.CompileURI(uri, opts.drafts()...)
+		report := schemaReport{Schema: file, Valid: result.Valid()}
+		if sc != nil {
+			report.Draft = sc.Draft()
+		}
+		if result != nil {
+			report.Errors = errorsJSON(result.Errors)
+			report.Warnings = errorsJSON(result.Warnings)
+		}
+		if !report.Valid || (strict && len(report.Warnings) > 0) {
+			code = exitInvalid
+		}
+
+		if opts.format == "json" {
+			reports = append(reports, report)
+			continue
+		}
+		switch {
+		case !report.Valid:
+			fmt.Printf("%v: invalid\n", file)
+		case report.Draft != "":
+			fmt.Printf("%v: ok (%v)\n", file, report.Draft)
+		default:
+			fmt.Printf("%v: ok\n", file)
+		}
+		if result != nil {
+			printErrors(os.Stdout, file, "error: ", result.Errors)
+			printErrors(os.Stdout, file, "warning: ", result.Warnings)
+		}
+	}
+
+	if opts.format == "json" {
+		printJSON(os.Stdout, reports)
+	}
+	return code
+}
+
+// compileSchema compiles the schema file for validate,
+// errors are printed to stderr.
+func compileSchema(opts *options, file string) *schema.Schema {
+	uri, err := schemaURI(file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "jsonschema validate: %v: %v\n", file, err)
+		return nil
+	}
+	sc, result := opts.registry().CompileURI(uri, opts.drafts()...)
+	if !result.Valid() {
+		fmt.Fprintf(os.Stderr, "jsonschema validate: %v: invalid schema\n", file)
+		printErrors(os.Stderr, file, "error: ", result.Errors)
+		return nil
+	}
+	return sc
+}
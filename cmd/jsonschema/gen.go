@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/eachain/jsonschema/gogen"
+)
+
+// gen generates Go types of a schema.
+func gen(args []string) int {
+	var opts options
+	var pkg, name, out string
+	fs := newFlagSet("gen", "schema.json")
+	opts.registerCompile(fs)
+	fs.StringVar(&pkg, "package", "schema", "package `name` of the generated source")
+	fs.StringVar(&name, "type", "Root", "type `name` of the root schema")
+	fs.StringVar(&out, "o", "", "write the source to `file` instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitError
+	}
+
+	file := fs.Arg(0)
+	uri, err := schemaURI(file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "jsonschema gen: %v: %v\n", file, err)
+		return exitError
+	}
+	loader := opts.loader()
+	js, err := loader.Load(uri)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "jsonschema gen: %v: %v\n", file, err)
+		return exitError
+	}
+
+	src, err := gogen.Generate(js, gogen.Options{
+		Package: pkg,
+		Name:    name,
+		URI:     uri,
+		Loader:  loader,
+		Draft:   opts.draft,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "jsonschema gen: %v: %v\n", file, err)
+		return exitInvalid
+	}
+
+	if out == "" {
+		os.Stdout.Write(src)
+		return exitOK
+	}
+	if err = os.WriteFile(out, src, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "jsonschema gen: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
@@ -0,0 +1,226 @@
+// Command jsonschema validates JSON documents against JSON Schemas
+// and reports mistakes in schemas.
+//
+// Usage:
+//
+//	jsonschema validate [flags] schema.json [instance.json|instance.yaml ...]
+//	jsonschema compile [flags] schema.json ...
+//	jsonschema lint [flags] schema.json ...
+//	jsonschema gen [flags] schema.json
+//
+// Instances are read from stdin if none or "-" is given. Files named
+// *.yaml or *.yml are parsed as YAML, including schemas, and every
+// document of a YAML instance is validated. With -stream, elements of a
+// top-level array or lines of NDJSON are validated one at a time, so huge
+// instances are never loaded as a whole. It exits with 0 if everything
+// is fine, 1 if any document is invalid, and 2 on usage or I/O errors.
+//
+// Both compile and lint report errors and warnings of schemas, lint
+// validates schemas against their meta-schemas too. Only errors fail
+// them, warnings do with -strict.
+//
+// The gen command writes Go types of a schema, see package gogen.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	schema "github.com/eachain/jsonschema"
+	_ "github.com/eachain/jsonschema/draft04"
+	_ "github.com/eachain/jsonschema/draft06"
+	_ "github.com/eachain/jsonschema/draft07"
+	_ "github.com/eachain/jsonschema/draft201909"
+	_ "github.com/eachain/jsonschema/draft202012"
+)
+
+const (
+	exitOK      = 0
+	exitInvalid = 1
+	exitError   = 2
+)
+
+const usage = `Usage:
+	jsonschema validate [flags] schema.json [instance.json ...]
+	jsonschema compile [flags] schema.json ...
+	jsonschema lint [flags] schema.json ...
+	jsonschema gen [flags] schema.json
+
+Run 'jsonschema <command> -h' for flags of the command.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(exitError)
+	}
+
+	var run func(args []string) int
+	switch os.Args[1] {
+	case "validate":
+		run = validate
+	case "compile":
+		run = compile
+	case "lint":
+		run = lint
+	case "gen":
+		run = gen
+	case "-h", "-help", "--help", "help":
+		fmt.Fprint(os.Stdout, usage)
+		os.Exit(exitOK)
+	default:
+		fmt.Fprintf(os.Stderr, "jsonschema: unknown command %q\n\n%v", os.Args[1], usage)
+		os.Exit(exitError)
+	}
+	os.Exit(run(os.Args[2:]))
+}
+
+// options are flags shared by commands.
+type options struct {
+	draft  string
+	format string
+	noHTTP bool
+}
+
+func (o *options) register(fs *flag.FlagSet) {
+	o.registerCompile(fs)
+	fs.StringVar(&o.format, "format", "text", "output `format`: text or json")
+}
+
+// registerCompile registers flags of compiling schemas only.
+func (o *options) registerCompile(fs *flag.FlagSet) {
+	fs.StringVar(&o.draft, "draft", "", "compile schemas as `draft`, eg. draft-07, detected if empty")
+	fs.BoolVar(&o.noHTTP, "no-http", false, "don't load referenced schemas by http(s)")
+}
+
+func (o *options) check() error {
+	if o.format != "text" && o.format != "json" {
+		return fmt.Errorf("unknown output format %q", o.format)
+	}
+	return nil
+}
+
+func (o *options) drafts() []string {
+	if o.draft == "" {
+		return nil
+	}
+	return []string{o.draft}
+}
+
+// loader loads local files named on the command line and the files they
+// refer to, and http(s):// uris unless -no-http is set.
+func (o *options) loader() schema.Loader {
+	if o.noHTTP {
+		return schema.FileLoader{}
+	}
+	return schema.Loaders{schema.FileLoader{}, schema.HTTPLoader{}}
+}
+
+func (o *options) registry() *schema.Registry {
+	reg := schema.NewRegistry()
+	reg.Loader = o.loader()
+	return reg
+}
+
+func newFlagSet(name, args string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: jsonschema %v [flags] %v\n\nFlags:\n", name, args)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// schemaURI converts a schema file name into the uri of the schema,
+// so relative references in it are resolved against its location.
+func schemaURI(name string) (string, error) {
+	if strings.HasPrefix(name, "http://") || strings.HasPrefix(name, "https://") ||
+		strings.HasPrefix(name, "file://") {
+		return name, nil
+	}
+	if _, err := os.Stat(name); err != nil {
+		return "", err
+	}
+	abs, err := filepath.Abs(name)
+	if err != nil {
+		return "", err
+	}
+	abs = filepath.ToSlash(abs)
+	if !strings.HasPrefix(abs, "/") {
+		abs = "/" + abs // windows volume
+	}
+	return "file://" + abs, nil
+}
+
+// errorJSON is an error or warning in json output.
+type errorJSON struct {
+	Path     string      `json:"path"`
+	Position string      `json:"position,omitempty"` // file:line:col
+	Type     string      `json:"type,omitempty"`
+	Value    interface{} `json:"value,omitempty"`
+	Message  string      `json:"message"`
+	Code     string      `json:"code,omitempty"`
+	Keyword  string      `json:"keyword,omitempty"`
+	Causes   []errorJSON `json:"causes,omitempty"` // errors of branches of anyOf or oneOf
+}
+
+func errorsJSON(errs []schema.Error) []errorJSON {
+	list := make([]errorJSON, 0, len(errs))
+	for _, e := range errs {
+		field := e.Field
+		if field == "" {
+			field = "."
+		}
+		var pos string
+		if p := e.Position(); p.IsValid() {
+			pos = p.String()
+		}
+		ej := errorJSON{
+			Path:     field,
+			Position: pos,
+			Type:     string(e.Type),
+			Value:    e.Value,
+			Message:  e.Msg,
+			Code:     string(e.Code),
+			Keyword:  e.Keyword,
+		}
+		if len(e.Causes) > 0 {
+			ej.Causes = errorsJSON(e.Causes)
+		}
+		list = append(list, ej)
+	}
+	return list
+}
+
+func printJSON(w io.Writer, v interface{}) {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
+
+// printErrors prints errs with positions as "file:line:col",
+// positions without file name are in file.
+func printErrors(w io.Writer, file, prefix string, errs []schema.Error) {
+	printIndented(w, "  ", file, prefix, errs)
+}
+
+// printIndented prints errs, and their causes indented more.
+func printIndented(w io.Writer, indent, file, prefix string, errs []schema.Error) {
+	for _, e := range errs {
+		if pos := e.Position(); pos.IsValid() {
+			if pos.File == "" {
+				pos.File = file
+			}
+			fmt.Fprintf(w, "%v%v: %v%v\n", indent, pos, prefix, e.Error())
+		} else {
+			fmt.Fprintf(w, "%v%v%v\n", indent, prefix, e.Error())
+		}
+		printIndented(w, indent+"  ", file, prefix, e.Causes)
+	}
+}
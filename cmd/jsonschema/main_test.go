@@ -0,0 +1,120 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// run runs command cmd with args, and returns its exit code and output.
+func run(t *testing.T, cmd func(args []string) int, args ...string) (int, string) {
+	t.Helper()
+	out, err := os.CreateTemp(t.TempDir(), "out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = out, out
+	defer func() { os.Stdout, os.Stderr = stdout, stderr }()
+
+	code := cmd(args)
+	b, err := os.ReadFile(out.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return code, string(b)
+}
+
+// writeFiles writes files named by keys into a temporary directory,
+// and changes into it.
+func writeFiles(t *testing.T, files map[string]string) {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+func TestValidate(t *testing.T) {
+	writeFiles(t, map[string]string{
+		"schema.json":  `{"type": "object", "properties": {"a": {"type": "integer"}}}`,
+		"ok.json":      "{\"a\": 1}\n",
+		"bad.json":     `{"a": "x"}`,
+		"trail.json":   `{"a": 1} x`,
+		"two.json":     "{\"a\": 1}\n{\"a\": 2}\n",
+		"ok.yaml":      "a: 1\n---\na: 2\n",
+		"array.json":   "[{\"a\": 1},\n {\"a\": \"x\"}]",
+		"lines.ndjson": "{\"a\": 1}\n{\"a\": \"x\"}\n",
+	})
+
+	tests := []struct {
+		args []string
+		code int
+		out  []string
+	}{
+		{[]string{"schema.json", "ok.json", "ok.yaml"}, exitOK, []string{"ok.json: ok", "ok.yaml (document 2): ok"}},
+		{[]string{"schema.json", "ok.json", "bad.json"}, exitInvalid, []string{"bad.json: invalid", "bad.json:1:7: path #/a"}},
+		{[]string{"schema.json", "trail.json"}, exitError, []string{"trail.json:1:11: invalid character 'x'"}},
+		{[]string{"schema.json", "two.json"}, exitError, []string{"two.json:2:2: invalid token after top-level value"}},
+		{[]string{"-stream", "array", "schema.json", "array.json"}, exitInvalid, []string{"array.json (element 1): invalid", "array.json:2:8: path #/a", "2 values, 1 invalid"}},
+		{[]string{"-stream", "ndjson", "schema.json", "lines.ndjson"}, exitInvalid, []string{"lines.ndjson (line 2): invalid", "lines.ndjson:2:7: path #/a"}},
+		{[]string{"-format", "json", "schema.json", "bad.json"}, exitInvalid, []string{`"instance": "bad.json"`, `"valid": false`}},
+		{[]string{"schema.json", "none.json"}, exitError, []string{"none.json"}},
+		{[]string{"-stream", "x", "schema.json"}, exitError, []string{"unknown stream mode"}},
+	}
+	for _, tt := range tests {
+		code, out := run(t, validate, tt.args...)
+		if code != tt.code {
+			t.Errorf("validate %v: exit %d, want %d:\n%v", tt.args, code, tt.code, out)
+		}
+		for _, s := range tt.out {
+			if !strings.Contains(out, s) {
+				t.Errorf("validate %v: output without %q:\n%v", tt.args, s, out)
+			}
+		}
+	}
+}
+
+func TestLint(t *testing.T) {
+	writeFiles(t, map[string]string{
+		"ok.json":      `{"type": "object", "properties": {"a": {"type": "integer"}}}`,
+		"warned.json":  `{"properties": {"a": {"minLength": 1}}}`,
+		"invalid.json": `{"$schema": "http://json-schema.org/draft-07/schema#", "minLength": "x"}`,
+	})
+
+	tests := []struct {
+		cmd  func([]string) int
+		args []string
+		code int
+	}{
+		{lint, []string{"-no-http", "ok.json"}, exitOK},
+		{lint, []string{"-no-http", "warned.json"}, exitOK},
+		{lint, []string{"-no-http", "-strict", "warned.json"}, exitInvalid},
+		{lint, []string{"-no-http", "invalid.json"}, exitInvalid},
+		{compile, []string{"-no-http", "warned.json"}, exitOK},
+		{compile, []string{"-no-http", "-strict", "warned.json"}, exitInvalid},
+		{compile, []string{"-no-http", "invalid.json"}, exitInvalid},
+	}
+	for _, tt := range tests {
+		code, out := run(t, tt.cmd, tt.args...)
+		if code != tt.code {
+			t.Errorf("%v: exit %d, want %d:\n%v", tt.args, code, tt.code, out)
+		}
+	}
+
+	_, out := run(t, lint, "-no-http", "warned.json")
+	if !strings.Contains(out, "warned.json:1:") || !strings.Contains(out, "warning: ") {
+		t.Errorf("warnings not reported with positions:\n%v", out)
+	}
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	schema "github.com/eachain/jsonschema"
+	"github.com/eachain/jsonschema/jsi"
+)
+
+// instanceReport is the json output of validate.
+type instanceReport struct {
+	Instance string `json:"instance"`
+	Document int    `json:"document,omitempty"` // index from 1 in a multi-document YAML
+	Index    *int   `json:"index,omitempty"`    // element index or value index with -stream
+	Line     int    `json:"line,omitempty"`     // line of the value with -stream
+	Error    string `json:"error,omitempty"`    // failed reading the instance
+	*schema.OutputUnit
+}
+
+// validate validates instances against a schema,
+// any invalid instance fails it.
+func validate(args []string) int {
+	var opts options
+	var output string
+	var yaml bool
+	var stream string
+	var maxErrors int
+	fs := newFlagSet("validate", "schema.json [instance.json ...]")
+	opts.register(fs)
+	fs.StringVar(&output, "output", string(schema.BasicOutput),
+		"json output `structure`: flag, basic, detailed or verbose")
+	fs.BoolVar(&yaml, "yaml", false, "parse instances as YAML, detected by file extension if not set")
+	fs.IntVar(&maxErrors, "max-errors", 0, "stop validating an instance after `n` errors, 0 for all")
+	fs.StringVar(&stream, "stream", "", "validate elements of a top-level array or lines one by one, "+
+		"reporting invalid ones only: `mode` array or ndjson")
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+	if err := opts.check(); err != nil {
+		fmt.Fprintf(os.Stderr, "jsonschema validate: %v\n", err)
+		return exitError
+	}
+	switch schema.OutputFormat(output) {
+	case schema.FlagOutput, schema.BasicOutput, schema.DetailedOutput, schema.VerboseOutput:
+	default:
+		fmt.Fprintf(os.Stderr, "jsonschema validate: unknown output structure %q\n", output)
+		return exitError
+	}
+	if stream != "" && stream != "array" && stream != "ndjson" {
+		fmt.Fprintf(os.Stderr, "jsonschema validate: unknown stream mode %q\n", stream)
+		return exitError
+	}
+	if stream != "" && yaml {
+		fmt.Fprintf(os.Stderr, "jsonschema validate: -stream doesn't work with -yaml\n")
+		return exitError
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitError
+	}
+
+	sc := compileSchema(&opts, fs.Arg(0))
+	if sc == nil {
+		return exitError
+	}
+	var vopts []schema.ValidateOption
+	if maxErrors > 0 {
+		vopts = append(vopts, schema.WithMaxErrors(maxErrors))
+	}
+
+	files := fs.Args()[1:]
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	code := exitOK
+	var reports []instanceReport
+	for _, file := range files {
+		if stream != "" {
+			if c := validateStream(sc, vopts, file, stream, schema.OutputFormat(output), &opts, &reports); c > code {
+				code = c
+			}
+			continue
+		}
+
+		docs, err := parseInstances(file, yaml)
+		if err != nil {
+			code = exitError
+			if opts.format == "json" {
+				reports = append(reports, instanceReport{Instance: file, Error: err.Error()})
+			} else {
+				fmt.Fprintf(os.Stderr, "jsonschema validate: %v\n", err)
+			}
+		}
+
+		for i, js := range docs {
+			result := sc.Validate(js, vopts...)
+			if !result.Valid() && code == exitOK {
+				code = exitInvalid
+			}
+
+			report := instanceReport{Instance: file}
+			name := file
+			if len(docs) > 1 {
+				report.Document = i + 1
+				name = fmt.Sprintf("%v (document %d)", file, i+1)
+			}
+			if opts.format == "json" {
+				report.OutputUnit = result.Output(schema.OutputFormat(output))
+				reports = append(reports, report)
+				continue
+			}
+			if result.Valid() {
+				fmt.Printf("%v: ok\n", name)
+			} else {
+				fmt.Printf("%v: invalid\n", name)
+				printErrors(os.Stdout, file, "", result.Errors)
+			}
+		}
+	}
+
+	if opts.format == "json" {
+		printJSON(os.Stdout, reports)
+	}
+	return code
+}
+
+// validateStream validates an instance one value at a time,
+// only invalid values are reported.
+func validateStream(sc *schema.Schema, vopts []schema.ValidateOption, file, mode string,
+	output schema.OutputFormat, opts *options, reports *[]instanceReport) int {
+	r := io.Reader(os.Stdin)
+	if file != "-" {
+		fp, err := os.Open(file)
+		if err != nil {
+			return streamError(file, positioned(file, err), opts, reports)
+		}
+		defer fp.Close()
+		r = fp
+	}
+
+	name := "element"
+	validate := sc.ValidateArray
+	if mode == "ndjson" {
+		name = "line"
+		validate = sc.ValidateNDJSON
+	}
+
+	code := exitOK
+	count, invalid := 0, 0
+	err := validate(r, func(sr schema.StreamResult) error {
+		count++
+		if sr.Valid() {
+			return nil
+		}
+		invalid++
+		code = exitInvalid
+
+		if opts.format == "json" {
+			index := sr.Index
+			*reports = append(*reports, instanceReport{
+				Instance:   file,
+				Index:      &index,
+				Line:       sr.Pos.Line,
+				OutputUnit: sr.Output(output),
+			})
+			return nil
+		}
+		n := sr.Index
+		if mode == "ndjson" {
+			n = sr.Pos.Line
+		}
+		fmt.Printf("%v (%v %d): invalid\n", file, name, n)
+		printErrors(os.Stdout, file, "", sr.Errors)
+		return nil
+	}, vopts...)
+	if err != nil {
+		return streamError(file, positioned(file, err), opts, reports)
+	}
+	if opts.format != "json" {
+		fmt.Printf("%v: %d values, %d invalid\n", file, count, invalid)
+	}
+	return code
+}
+
+func streamError(file string, err error, opts *options, reports *[]instanceReport) int {
+	if opts.format == "json" {
+		*reports = append(*reports, instanceReport{Instance: file, Error: err.Error()})
+	} else {
+		fmt.Fprintf(os.Stderr, "jsonschema validate: %v\n", err)
+	}
+	return exitError
+}
+
+// parseInstances parses a JSON instance, or all documents of a YAML
+// instance. Documents parsed before an error are returned with it,
+// errors are prefixed with file and position.
+func parseInstances(file string, yaml bool) ([]jsi.JSON, error) {
+	var p jsi.Parser
+	switch {
+	case file == "-" && yaml:
+		p = jsi.NewYAMLParser(os.Stdin)
+	case file == "-":
+		p = jsi.NewReaderParser(os.Stdin, jsi.WithNoTrailingData())
+	case yaml || jsi.IsYAMLFile(file):
+		p = jsi.NewYAMLFileParser(file)
+	default:
+		p = jsi.NewFileParser(file, jsi.WithNoTrailingData())
+	}
+
+	if !yaml && (file == "-" || !jsi.IsYAMLFile(file)) {
+		js, err := p.Parse()
+		if err != nil {
+			return nil, positioned(file, err)
+		}
+		return []jsi.JSON{js}, nil
+	}
+
+	var docs []jsi.JSON
+	for {
+		js, err := p.Parse()
+		if err == io.EOF {
+			if len(docs) == 0 {
+				return nil, fmt.Errorf("%v: no YAML document", file)
+			}
+			return docs, nil
+		}
+		if err != nil {
+			return docs, positioned(file, err)
+		}
+		docs = append(docs, js)
+	}
+}
+
+// positioned prefixes err with file, and line and column of syntax
+// and limit errors, as "file:line:col: err".
+func positioned(file string, err error) error {
+	var pos jsi.Position
+	var se *jsi.SyntaxError
+	var le *jsi.LimitError
+	switch {
+	case errors.As(err, &se):
+		pos = se.Position
+	case errors.As(err, &le):
+		pos = le.Position
+	}
+	if pos.IsValid() {
+		return fmt.Errorf("%v:%d:%d: %w", file, pos.Line, pos.Column, err)
+	}
+	return fmt.Errorf("%v: %w", file, err)
+}
@@ -0,0 +1,46 @@
+package jsonschema
+
+// Code identifies the kind of a validation error, it never changes
+// with the message text.
+type Code string
+
+// Params are the typed params of an error, eg. "limit" and "actual" of
+// CodeMaxLength, to render the message of the error.
+type Params map[string]interface{}
+
+const (
+	CodeFalseSchema           Code = "false_schema"
+	CodeType                  Code = "type"              // expected []string, actual string
+	CodeConst                 Code = "const"             // const jsi.JSON
+	CodeEnum                  Code = "enum"              // allowed []jsi.JSON
+	CodeInvalidNumber         Code = "invalid_number"    // number can't be parsed
+	CodeMultipleOf            Code = "multiple_of"       // divisor string
+	CodeMaximum               Code = "maximum"           // op, limit string
+	CodeExclusiveMaximum      Code = "exclusive_maximum" // op, limit string
+	CodeMinimum               Code = "minimum"           // op, limit string
+	CodeExclusiveMinimum      Code = "exclusive_minimum" // op, limit string
+	CodeCompare               Code = "compare"           // op, limit string
+	CodeMaxLength             Code = "max_length"        // limit, actual int
+	CodeMinLength             Code = "min_length"        // limit, actual int
+	CodePattern               Code = "pattern"           // pattern string
+	CodeFormat                Code = "format"            // format string
+	CodeMaxItems              Code = "max_items"         // limit, actual int
+	CodeMinItems              Code = "min_items"         // limit, actual int
+	CodeUniqueItems           Code = "unique_items"      // i, j int
+	CodeContains              Code = "contains"
+	CodeMaxContains           Code = "max_contains" // limit, actual int
+	CodeMinContains           Code = "min_contains" // limit, actual int
+	CodeUnevaluatedItems      Code = "unevaluated_items"
+	CodeMaxProperties         Code = "max_properties" // limit, actual int
+	CodeMinProperties         Code = "min_properties" // limit, actual int
+	CodeRequired              Code = "required"       // missing []string
+	CodeAdditionalProperties  Code = "additional_properties"
+	CodeUnevaluatedProperties Code = "unevaluated_properties"
+	CodeAnyOf                 Code = "any_of"
+	CodeOneOf                 Code = "one_of" // matched int, branches []int if more than one
+	CodeNot                   Code = "not"
+	CodeUnresolvedRef         Code = "unresolved_ref"   // ref, id string
+	CodeReferenceFailed       Code = "reference_failed" // reason string
+	CodeRefCycle              Code = "ref_cycle"        // ref string
+	CodeCanceled              Code = "canceled"         // reason string
+)
@@ -0,0 +1,23 @@
+package jsonschema
+
+import "github.com/eachain/jsonschema/jsi"
+
+// Coercion is a value converted to the type expected by its schema,
+// as validated WithCoerceTypes.
+type Coercion struct {
+	Field string // instance location
+	From  jsi.JSON
+	To    jsi.JSON
+}
+
+func WithCoercion(c Coercion) *Result {
+	return &Result{Coercions: []Coercion{c}}
+}
+
+func (r *Result) WithCoercion(c Coercion) *Result {
+	if r == nil {
+		return &Result{Coercions: []Coercion{c}}
+	}
+	r.Coercions = append(r.Coercions, c)
+	return r
+}
@@ -0,0 +1,92 @@
+package jsonschema_test
+
+import (
+	"fmt"
+	"testing"
+
+	schema "github.com/eachain/jsonschema"
+)
+
+func TestCoerceTypes(t *testing.T) {
+	tests := []struct {
+		typ, instance, want string
+	}{
+		{`"integer"`, `"42"`, `42`},
+		{`"integer"`, `true`, `1`},
+		{`"integer"`, `null`, `0`},
+		{`"number"`, `"-1.5"`, `-1.5`},
+		{`"number"`, `false`, `0`},
+		{`"string"`, `1.5`, `"1.5"`},
+		{`"string"`, `true`, `"true"`},
+		{`"string"`, `null`, `""`},
+		{`"boolean"`, `"false"`, `false`},
+		{`"boolean"`, `1`, `true`},
+		{`"boolean"`, `null`, `false`},
+		{`"null"`, `""`, `null`},
+		{`"null"`, `0`, `null`},
+		{`"array"`, `"a"`, `["a"]`},
+		// the first type converted to is taken
+		{`["boolean", "integer"]`, `"1"`, `1`},
+		{`["integer", "boolean"]`, `"true"`, `true`},
+		// values of the types are kept
+		{`["string", "integer"]`, `"1"`, `"1"`},
+	}
+	for _, tt := range tests {
+		got, result := apply(t, `{"type": `+tt.typ+`}`, tt.instance, schema.WithCoerceTypes())
+		if !result.Valid() {
+			t.Errorf("%v to %v: %v", tt.instance, tt.typ, result.Error())
+		}
+		if got != tt.want {
+			t.Errorf("%v to %v: got %v, want %v", tt.instance, tt.typ, got, tt.want)
+		}
+	}
+}
+
+func TestCoerceTypesFailed(t *testing.T) {
+	tests := []struct {
+		schema, instance string
+	}{
+		{`{"type": "integer"}`, `"4.5"`},
+		{`{"type": "integer"}`, `"x"`},
+		{`{"type": "boolean"}`, `"yes"`},
+		{`{"type": "array"}`, `{}`},
+		// not converted where failing doesn't fail the instance
+		{`{"anyOf": [{"type": "integer"}]}`, `"42"`},
+		{`{"not": {"type": "string"}}`, `"42"`},
+	}
+	for _, tt := range tests {
+		if got, result := apply(t, tt.schema, tt.instance, schema.WithCoerceTypes()); result.Valid() || got != tt.instance {
+			t.Errorf("%v with %v: got %v, %v", tt.schema, tt.instance, got, result.Error())
+		}
+	}
+
+	// values converted are validated
+	if got, result := apply(t, `{"type": "integer", "minimum": 50}`, `"42"`, schema.WithCoerceTypes()); result.Valid() || got != `42` {
+		t.Errorf("got %v, %v", got, result.Error())
+	}
+
+	// only with the option
+	if _, result := apply(t, `{"type": "integer"}`, `"42"`); result.Valid() {
+		t.Errorf("converted without option")
+	}
+}
+
+func TestCoercions(t *testing.T) {
+	s := `{"properties": {
+		"i": {"type": "integer"},
+		"a": {"type": "array", "items": {"type": "integer"}},
+		"s": {"type": "string"}
+	}}`
+	got, result := apply(t, s, `{"i": "1", "a": "2", "s": "x"}`, schema.WithCoerceTypes())
+	if !result.Valid() || got != `{"i":1,"a":[2],"s":"x"}` {
+		t.Fatalf("got %v, %v", got, result.Error())
+	}
+	var coercions []string
+	for _, c := range result.Coercions {
+		coercions = append(coercions, fmt.Sprintf("%v %v->%v", c.Field, c.From, c.To))
+	}
+	want := `[#/i "1"->1 #/a "2"->[2] #/a/0 "2"->2]`
+	if fmt.Sprint(coercions) != want {
+		t.Errorf("coercions: got %v, want %v", coercions, want)
+	}
+}
@@ -1,6 +1,10 @@
 package jsonschema
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
 	"strconv"
 
 	"github.com/eachain/jsonschema/jsi"
@@ -14,7 +18,45 @@ type Context struct {
 	index  string
 	impl   map[string]Validator
 	ref    map[string][]*Reference
+	nodes  map[string]*subSchema // schemas by uri, filled in by FillRefs
+	dyn    map[*subSchema]map[string]Validator
+	loader Loader
+	loaded map[string]bool // documents loaded by Load and the one compiled
 	parent *Context
+
+	evaluating *bool
+	dynamic    *dynamicScope
+	entered    *enteredRef
+	annotating bool
+	defaults   bool
+	coercing   bool
+	removing   RemoveMode
+	cancel     context.Context
+	maxErrors  int
+	replaced   map[jsi.JSON]jsi.JSON // values replaced by Replace, made if coercing
+
+	// keyword locations at validation time, *schema of the schema entered
+	keyword   string
+	kwschema  *Pointer
+	kwloc     *Pointer
+	absschema *Pointer
+	absloc    *Pointer
+}
+
+// dynamicScope is the chain of schema resources entered during validation,
+// innermost first, as needed by $recursiveRef and $dynamicRef.
+type dynamicScope struct {
+	anchor    string
+	validator Validator
+	outer     *dynamicScope
+}
+
+// enteredRef is the chain of references entered during validation,
+// innermost first, to tell a reference applied to the same instance again.
+type enteredRef struct {
+	loc   string // absolute keyword location of the reference
+	field string // instance location
+	outer *enteredRef
 }
 
 type Reference struct {
@@ -22,6 +64,8 @@ type Reference struct {
 	*Context
 	jsi.JSON
 	Validator *Validator
+
+	cycled bool // reported in a cycle by RefCycles
 }
 
 func newContext(draft string) *Context {
@@ -32,6 +76,16 @@ func newContext(draft string) *Context {
 		path:   new(Pointer),
 		impl:   make(map[string]Validator),
 		ref:    make(map[string][]*Reference),
+		nodes:  make(map[string]*subSchema),
+		dyn:    make(map[*subSchema]map[string]Validator),
+		loaded: make(map[string]bool),
+
+		evaluating: new(bool),
+
+		kwschema:  new(Pointer),
+		kwloc:     new(Pointer),
+		absschema: new(Pointer),
+		absloc:    new(Pointer),
 	}
 }
 
@@ -44,7 +98,28 @@ func (ctx *Context) clone() *Context {
 		index:  ctx.index,
 		impl:   ctx.impl,
 		ref:    ctx.ref,
+		nodes:  ctx.nodes,
+		dyn:    ctx.dyn,
+		loader: ctx.loader,
+		loaded: ctx.loaded,
 		parent: ctx,
+
+		evaluating: ctx.evaluating,
+		dynamic:    ctx.dynamic,
+		entered:    ctx.entered,
+		annotating: ctx.annotating,
+		defaults:   ctx.defaults,
+		coercing:   ctx.coercing,
+		removing:   ctx.removing,
+		cancel:     ctx.cancel,
+		maxErrors:  ctx.maxErrors,
+		replaced:   ctx.replaced,
+
+		keyword:   ctx.keyword,
+		kwschema:  ctx.kwschema,
+		kwloc:     ctx.kwloc,
+		absschema: ctx.absschema,
+		absloc:    ctx.absloc,
 	}
 }
 
@@ -76,7 +151,13 @@ func (ctx *Context) SetId(id *Pointer) {
 }
 
 func (ctx *Context) SetAnchor(anchor string) {
-	ctx.schema.anchor = anchor
+	ctx.schema.anchors = append(ctx.schema.anchors, anchor)
+}
+
+// SetDynamicAnchor marks the schema as a target of $dynamicRef,
+// it should be set as a plain anchor too.
+func (ctx *Context) SetDynamicAnchor(anchor string) {
+	ctx.schema.dynamicAnchor = anchor
 }
 
 func (ctx *Context) Index() string {
@@ -98,7 +179,7 @@ func (ctx *Context) Object(key string) *Context {
 	key = escape(key)
 	sc := ctx.clone()
 	sc.schema = ctx.schema.loadOrNew(key)
-	sc.path = ctx.path.Object(key)
+	sc.path = ctx.path.escapedIndex(key)
 	sc.index = key
 	return sc
 }
@@ -127,8 +208,23 @@ func (ctx *Context) Impl(val Validator) {
 	ctx.schema.validator = val
 }
 
+// SetSchema records js as the schema compiled at ctx,
+// Schema.Resolve returns it for references resolved to it.
+func (ctx *Context) SetSchema(js jsi.JSON) {
+	ctx.schema.js = js
+}
+
+// PushDynamicAnchors asks for the dynamic anchors defined in the schema
+// resource at ctx, anchors is filled in by FillRefs.
+func (ctx *Context) PushDynamicAnchors(anchors map[string]Validator) {
+	ctx.dyn[ctx.schema] = anchors
+}
+
 func (ctx *Context) FillRefs() []*Reference {
 	ctx.spread()
+	for s, anchors := range ctx.dyn {
+		s.dynamicAnchors(anchors)
+	}
 	var refs []*Reference
 	for uri, rs := range ctx.ref {
 		impl := ctx.impl[uri]
@@ -144,6 +240,321 @@ func (ctx *Context) FillRefs() []*Reference {
 	return refs
 }
 
+// resolved returns schemas which references resolve to, by the values
+// of reference keywords, after FillRefs.
+func (ctx *Context) resolved() map[jsi.JSON]jsi.JSON {
+	ctx.spread()
+	refs := make(map[jsi.JSON]jsi.JSON)
+	for uri, rs := range ctx.ref {
+		s := ctx.nodes[uri]
+		if s == nil || s.js == nil {
+			continue // a resource compiled before by Registry
+		}
+		for _, r := range rs {
+			refs[r.JSON] = s.js
+		}
+	}
+	return refs
+}
+
 func (ctx *Context) spread() {
-	ctx.schema.visit(ctx.impl, ctx.id)
+	ctx.schema.visit(ctx.nodes, ctx.id)
+	for uri, s := range ctx.nodes {
+		ctx.impl[uri] = s.validator
+	}
+}
+
+// RefCycles returns cycles of references resolved by FillRefs, each
+// reference of a cycle resolves to a schema applying the next reference
+// to the same instance, and the last one applies the first. Only
+// references of keyword are followed, inPlace tells whether a schema
+// applies the subschema at keys to its instance, eg. ["allOf", "0"].
+// A cycle is returned once, ordered from the reference first by Field.
+func (ctx *Context) RefCycles(keyword string, inPlace func(keys []string) bool) [][]*Reference {
+	// references applied by each schema to its instance
+	applied := make(map[*subSchema][]*Reference)
+	var refs []*Reference
+	for _, rs := range ctx.ref {
+		for _, r := range rs {
+			if r.index != keyword || r.schema.parent == nil {
+				continue
+			}
+			refs = append(refs, r)
+			s := r.schema.parent
+			applied[s] = append(applied[s], r)
+			var keys []string
+			for sub, p := s, s.parent; p != nil; sub, p = p, p.parent {
+				keys = append([]string{sub.key}, keys...)
+				if p.validator == nil {
+					continue // keyword of p
+				}
+				if !inPlace(keys) {
+					break
+				}
+				applied[p] = append(applied[p], r)
+				keys = nil
+			}
+		}
+	}
+	sort.Slice(refs, func(i, j int) bool { return refs[i].Field() < refs[j].Field() })
+
+	const visiting, visited = 1, 2
+	state := make(map[*Reference]int)
+	var stack []*Reference
+	var cycles [][]*Reference
+	var walk func(r *Reference)
+	walk = func(r *Reference) {
+		state[r] = visiting
+		stack = append(stack, r)
+		for _, next := range applied[ctx.nodes[r.Ref.String()]] {
+			switch state[next] {
+			case 0:
+				walk(next)
+			case visiting:
+				i := len(stack) - 1
+				for stack[i] != next {
+					i--
+				}
+				cycle := append([]*Reference(nil), stack[i:]...)
+				if !anyCycled(cycle) {
+					for _, c := range cycle {
+						c.cycled = true
+					}
+					cycles = append(cycles, cycle)
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[r] = visited
+	}
+	for _, r := range refs {
+		if state[r] == 0 {
+			walk(r)
+		}
+	}
+	return cycles
+}
+
+func anyCycled(refs []*Reference) bool {
+	for _, r := range refs {
+		if r.cycled {
+			return true
+		}
+	}
+	return false
+}
+
+// Load loads the schema document at uri by the loader of compiling,
+// official meta-schemas are loaded from MetaLoader without the loader.
+// A document is loaded once, references to it are resolved by compiling
+// it then, so loading it again fails rather than compiling it endlessly.
+func (ctx *Context) Load(uri string) (jsi.JSON, error) {
+	if ctx.loaded[uri] {
+		return nil, fmt.Errorf("reference not found in %v", uri)
+	}
+	ctx.loaded[uri] = true
+	if js, err := MetaLoader.Load(uri); !errors.Is(err, ErrNotFound) {
+		return js, err
+	}
+	if ctx.loader == nil {
+		return DefaultLoader.Load(uri)
+	}
+	return ctx.loader.Load(uri)
+}
+
+// TrackEvaluated is called at compile time by keywords that need to know
+// which properties and items have been evaluated, eg. unevaluatedProperties.
+func (ctx *Context) TrackEvaluated() {
+	*ctx.evaluating = true
+}
+
+// Evaluating reports whether validators should record evaluated
+// properties and items in their results.
+func (ctx *Context) Evaluating() bool {
+	return *ctx.evaluating
+}
+
+// WithDynamicScope enters a schema resource that defines the dynamic anchor.
+func (ctx *Context) WithDynamicScope(anchor string, val Validator) *Context {
+	sc := ctx.clone()
+	sc.dynamic = &dynamicScope{
+		anchor:    anchor,
+		validator: val,
+		outer:     ctx.dynamic,
+	}
+	return sc
+}
+
+// DynamicScope returns the outermost validator entered with the dynamic anchor.
+func (ctx *Context) DynamicScope(anchor string) Validator {
+	var val Validator
+	for ds := ctx.dynamic; ds != nil; ds = ds.outer {
+		if ds.anchor == anchor {
+			val = ds.validator
+		}
+	}
+	return val
+}
+
+// EnterRef is called at validation time before applying the schema which
+// the reference being applied resolves to. It returns false if the reference
+// has been entered at the same instance location, where following it again
+// would recurse infinitely.
+func (ctx *Context) EnterRef() (*Context, bool) {
+	loc, field := ctx.AbsoluteKeywordLocation(), ctx.Field()
+	for r := ctx.entered; r != nil; r = r.outer {
+		if r.loc == loc && r.field == field {
+			return ctx, false
+		}
+	}
+	sc := ctx.clone()
+	sc.entered = &enteredRef{loc: loc, field: field, outer: ctx.entered}
+	return sc, true
+}
+
+// Annotating reports whether annotations should be collected.
+func (ctx *Context) Annotating() bool {
+	return ctx.annotating
+}
+
+// Location returns the absolute location of the schema being compiled,
+// based on the id of its schema resource.
+func (ctx *Context) Location() *Pointer {
+	return ctx.schema.path.clone()
+}
+
+// EnterSchema is called at validation time when entering the schema
+// compiled at loc.
+func (ctx *Context) EnterSchema(loc *Pointer) *Context {
+	sc := ctx.clone()
+	sc.keyword = ""
+	sc.kwschema = ctx.kwloc
+	sc.absschema = loc
+	sc.absloc = loc
+	return sc
+}
+
+// WithKeyword is called at validation time before applying
+// the keyword of the schema entered.
+func (ctx *Context) WithKeyword(keyword string) *Context {
+	sc := ctx.clone()
+	sc.keyword = keyword
+	sc.kwloc = ctx.kwschema.Object(keyword)
+	sc.absloc = ctx.absschema.Object(keyword)
+	return sc
+}
+
+// SubSchema is called at validation time by keywords like properties
+// or allOf, before applying the subschema at key of the keyword.
+func (ctx *Context) SubSchema(key string) *Context {
+	sc := ctx.clone()
+	sc.kwloc = ctx.kwloc.Object(key)
+	sc.absloc = ctx.absloc.Object(key)
+	return sc
+}
+
+// Keyword returns the keyword being applied at validation time.
+func (ctx *Context) Keyword() string {
+	return ctx.keyword
+}
+
+// KeywordLocation returns the location of the keyword being applied,
+// relative to the root schema and through any reference.
+func (ctx *Context) KeywordLocation() string {
+	return ctx.kwloc.String()
+}
+
+// AbsoluteKeywordLocation returns the location of the keyword being applied
+// in its schema resource.
+func (ctx *Context) AbsoluteKeywordLocation() string {
+	return ctx.absloc.String()
+}
+
+// Defaulting reports whether missing members and items should be filled
+// in by defaults of their subschemas.
+func (ctx *Context) Defaulting() bool {
+	return ctx.defaults
+}
+
+// Tentative is called at validation time before applying subschemas
+// whose failure doesn't fail the instance, eg. branches of anyOf,
+// the instance is not changed by them.
+func (ctx *Context) Tentative() *Context {
+	sc := ctx.clone()
+	sc.defaults = false
+	sc.coercing = false
+	sc.removing = ""
+	if sc.maxErrors > 0 {
+		// only whether they fail matters
+		sc.maxErrors = 1
+	}
+	return sc
+}
+
+// Coercing reports whether values should be converted to types expected.
+func (ctx *Context) Coercing() bool {
+	return ctx.coercing
+}
+
+// Replace replaces instance js by val at validation time, and returns
+// the value replaced with, or nil if js can't be replaced. Validators
+// applied to the same instance later should get it by Current.
+func (ctx *Context) Replace(js, val jsi.JSON) jsi.JSON {
+	val = jsi.Replace(js, val)
+	if val != nil && ctx.replaced != nil {
+		ctx.replaced[js] = val
+	}
+	return val
+}
+
+// Current returns the value which js has been replaced with, or js.
+func (ctx *Context) Current(js jsi.JSON) jsi.JSON {
+	for {
+		val, ok := ctx.replaced[js]
+		if !ok {
+			return js
+		}
+		js = val
+	}
+}
+
+// RemovingAdditional returns how additional members of objects should be
+// removed, or "" if not.
+func (ctx *Context) RemovingAdditional() RemoveMode {
+	return ctx.removing
+}
+
+// Err returns the error of the context.Context validated with,
+// non-nil if validation is canceled, validators in loops should check it.
+func (ctx *Context) Err() error {
+	if ctx.cancel == nil {
+		return nil
+	}
+	return ctx.cancel.Err()
+}
+
+// Canceled returns an error of CodeCanceled validating js
+// if validation is canceled, or nil.
+func (ctx *Context) Canceled(js jsi.JSON) *Error {
+	err := ctx.Err()
+	if err == nil {
+		return nil
+	}
+	return &Error{
+		Field: ctx.Field(),
+		Type:  js.Type(),
+		Msg:   "validation canceled: " + err.Error(),
+
+		Code:                    CodeCanceled,
+		Params:                  Params{"reason": err.Error()},
+		Keyword:                 ctx.Keyword(),
+		KeywordLocation:         ctx.KeywordLocation(),
+		AbsoluteKeywordLocation: ctx.AbsoluteKeywordLocation(),
+	}
+}
+
+// Enough reports whether result has as many errors as wanted,
+// validators in loops should stop collecting more then.
+func (ctx *Context) Enough(result *Result) bool {
+	return ctx.maxErrors > 0 && result != nil && len(result.Errors) >= ctx.maxErrors
 }
@@ -0,0 +1,66 @@
+package jsonschema_test
+
+import (
+	"testing"
+
+	schema "github.com/eachain/jsonschema"
+)
+
+func hasCode(result *schema.Result, code schema.Code) bool {
+	if result == nil {
+		return false
+	}
+	for _, e := range result.Errors {
+		if e.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRefCycles(t *testing.T) {
+	tests := []struct {
+		schema string
+		drafts []string
+	}{
+		{`{"$ref": "#"}`, []string{"draft-202012"}},
+		{`{"definitions": {"a": {"$ref": "#/definitions/b"}, "b": {"$ref": "#/definitions/a"}}, "$ref": "#/definitions/a"}`, []string{"draft-07"}},
+		{`{"$defs": {"a": {"allOf": [{"$ref": "#/$defs/a"}]}}}`, []string{"draft-201909"}},
+		{`{"$defs": {"a": {"not": {"$ref": "#/$defs/a"}}}}`, []string{"draft-202012"}},
+		// declared by $schema, other drafts aren't tried
+		{`{"$schema": "http://json-schema.org/draft-04/schema#",
+			"definitions": {"a": {"dependencies": {"x": {"$ref": "#/definitions/a"}}}},
+			"$ref": "#/definitions/a"}`, nil},
+	}
+	for _, tt := range tests {
+		_, result := schema.Compile(parse(t, tt.schema), tt.drafts...)
+		if !hasCode(result, schema.CodeRefCycle) {
+			t.Errorf("%v: got %v, want %v", tt.schema, result.Error(), schema.CodeRefCycle)
+		}
+	}
+}
+
+func TestRefNotCycles(t *testing.T) {
+	for _, s := range []string{
+		`{"properties": {"next": {"$ref": "#"}}}`,
+		`{"items": {"$ref": "#"}}`,
+		`{"$defs": {"a": {"anyOf": [{"type": "null"}, {"items": {"$ref": "#/$defs/a"}}]}}, "$ref": "#/$defs/a"}`,
+	} {
+		sc := compile(t, s, "draft-202012")
+		if result := sc.Validate(parse(t, `[[[null]]]`)); hasCode(result, schema.CodeRefCycle) {
+			t.Errorf("%v: %v", s, result.Error())
+		}
+	}
+}
+
+func TestDynamicRefCycle(t *testing.T) {
+	sc := compile(t, `{
+		"$id": "https://example.com/root",
+		"$dynamicAnchor": "node",
+		"$ref": "https://example.com/tree",
+		"$defs": {"tree": {"$id": "https://example.com/tree", "$dynamicAnchor": "node", "$dynamicRef": "#node"}}
+	}`, "draft-202012")
+	if result := sc.Validate(parse(t, `1`)); !hasCode(result, schema.CodeRefCycle) {
+		t.Errorf("got %v, want %v", result.Error(), schema.CodeRefCycle)
+	}
+}
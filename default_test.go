@@ -0,0 +1,66 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	schema "github.com/eachain/jsonschema"
+)
+
+// apply applies schema s to instance doc, returning the value in JSON.
+// The instance itself is never modified.
+func apply(t *testing.T, s, doc string, opts ...schema.ValidateOption) (string, *schema.Result) {
+	t.Helper()
+	sc := compile(t, s, "draft-202012")
+	js := parse(t, doc)
+	before, _ := json.Marshal(js)
+	out, result := sc.Apply(js, opts...)
+	if after, _ := json.Marshal(js); string(after) != string(before) {
+		t.Errorf("%v: instance modified: %s", doc, after)
+	}
+	got, err := json.Marshal(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(got), result
+}
+
+func TestDefaults(t *testing.T) {
+	tests := []struct {
+		schema, instance, want string
+	}{
+		{`{"properties": {"a": {"default": 1}, "b": {"default": [2]}}}`, `{"b": 3}`, `{"b":3,"a":1}`},
+		// defaults filled in are validated by subschemas of them
+		{`{"properties": {"b": {"default": {}, "properties": {"c": {"default": "x"}}}}}`, `{}`, `{"b":{"c":"x"}}`},
+		{`{"prefixItems": [{"default": 1}, {"default": 2}]}`, `[0]`, `[0,2]`},
+		{`{"prefixItems": [{"default": 1}, {"default": 2}]}`, `[]`, `[1,2]`},
+		{`{"items": {"properties": {"a": {"default": 1}}}}`, `[{}, {"a": 2}]`, `[{"a":1},{"a":2}]`},
+		{`{"allOf": [{"properties": {"a": {"default": 1}}}]}`, `{}`, `{"a":1}`},
+		// ignored where failing doesn't fail the instance
+		{`{"anyOf": [{"properties": {"a": {"default": 1}}}]}`, `{}`, `{}`},
+		{`{"if": {"properties": {"a": {"default": 1}}}}`, `{}`, `{}`},
+		{`{"not": {"properties": {"a": {"default": 1}, "b": {"const": 1}}, "required": ["b"]}}`, `{}`, `{}`},
+		{`{"properties": {"a": {"default": 1}}}`, `[]`, `[]`},
+	}
+	for _, tt := range tests {
+		got, result := apply(t, tt.schema, tt.instance, schema.WithDefaults())
+		if !result.Valid() {
+			t.Errorf("%v with %v: %v", tt.schema, tt.instance, result.Error())
+		}
+		if got != tt.want {
+			t.Errorf("%v with %v: got %v, want %v", tt.schema, tt.instance, got, tt.want)
+		}
+	}
+}
+
+func TestDefaultsValidated(t *testing.T) {
+	s := `{"properties": {"a": {"default": 1, "minimum": 2}}}`
+	if got, result := apply(t, s, `{}`, schema.WithDefaults()); result.Valid() || got != `{"a":1}` {
+		t.Errorf("invalid default: got %v, %v", got, result.Error())
+	}
+
+	// only with the option
+	if got, result := apply(t, s, `{}`); !result.Valid() || got != `{}` {
+		t.Errorf("without option: got %v, %v", got, result.Error())
+	}
+}
@@ -30,6 +30,9 @@ func exclusiveMaximum(ctx *schema.Context, js jsi.JSON) (schema.Validator, *sche
 				Type:  js.Type(),
 				Value: js,
 				Msg:   "should lt " + num,
+
+				Code:   schema.CodeExclusiveMaximum,
+				Params: schema.Params{"op": "lt", "limit": num},
 			})
 		}
 		return nil
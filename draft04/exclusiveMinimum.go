@@ -30,6 +30,9 @@ func exclusiveMinimum(ctx *schema.Context, js jsi.JSON) (schema.Validator, *sche
 				Type:  js.Type(),
 				Value: js,
 				Msg:   "should gt " + num,
+
+				Code:   schema.CodeExclusiveMinimum,
+				Params: schema.Params{"op": "gt", "limit": num},
 			})
 		}
 		return nil
@@ -9,6 +9,7 @@ const Version = "draft-04"
 
 func init() {
 	schema.RegisterKeyword(Version, schema.RootKeyword, schema.CompileFunc(basic.RootObject))
+	schema.RegisterMetaSchema(Version, "http://json-schema.org/draft-04/schema#")
 
 	basic.RegisterPointer(Version, basic.PointerKeywords{
 		Id:  "id",
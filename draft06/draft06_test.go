@@ -0,0 +1,71 @@
+package draft06_test
+
+import (
+	"testing"
+
+	schema "github.com/eachain/jsonschema"
+	"github.com/eachain/jsonschema/draft06"
+	"github.com/eachain/jsonschema/jsi"
+)
+
+func TestKeywords(t *testing.T) {
+	tests := []struct {
+		schema   string
+		instance string
+		valid    bool
+	}{
+		{`{"const": {"a": [1, 2]}}`, `{"a": [1, 2]}`, true},
+		{`{"const": {"a": [1, 2]}}`, `{"a": [2, 1]}`, false},
+		{`{"const": null}`, `null`, true},
+		{`{"contains": {"type": "integer"}}`, `["a", 1]`, true},
+		{`{"contains": {"type": "integer"}}`, `["a", "b"]`, false},
+		{`{"contains": {"type": "integer"}}`, `[]`, false},
+		{`{"contains": {"type": "integer"}}`, `"not an array"`, true},
+		{`{"propertyNames": {"maxLength": 2}}`, `{"ab": 1}`, true},
+		{`{"propertyNames": {"maxLength": 2}}`, `{"abc": 1}`, false},
+		{`{"propertyNames": false}`, `{}`, true},
+		{`{"propertyNames": false}`, `{"a": 1}`, false},
+		{`true`, `"anything"`, true},
+		{`false`, `"anything"`, false},
+		{`{"properties": {"a": false}}`, `{"b": 1}`, true},
+		{`{"properties": {"a": false}}`, `{"a": 1}`, false},
+		{`{"items": [true, false]}`, `[1]`, true},
+		{`{"items": [true, false]}`, `[1, 2]`, false},
+		{`{"exclusiveMinimum": 1}`, `1`, false},
+		{`{"exclusiveMaximum": 3}`, `2.5`, true},
+		{`{"examples": [1, "a"], "type": "integer"}`, `1`, true},
+	}
+	for _, tt := range tests {
+		sc := compile(t, tt.schema)
+		result := sc.Validate(parse(t, tt.instance))
+		if result.Valid() != tt.valid {
+			t.Errorf("%v with %v: got valid %v, want %v: %v", tt.schema, tt.instance,
+				result.Valid(), tt.valid, result.Error())
+		}
+	}
+}
+
+func TestExclusiveMinimumNotBoolean(t *testing.T) {
+	_, result := schema.Compile(parse(t, `{"minimum": 1, "exclusiveMinimum": true}`), draft06.Version)
+	if result.Valid() {
+		t.Errorf("boolean exclusiveMinimum compiled as %v", draft06.Version)
+	}
+}
+
+func parse(t *testing.T, s string) jsi.JSON {
+	t.Helper()
+	js, err := jsi.NewBytesParser([]byte(s)).Parse()
+	if err != nil {
+		t.Fatalf("parse %s: %v", s, err)
+	}
+	return js
+}
+
+func compile(t *testing.T, s string) *schema.Schema {
+	t.Helper()
+	sc, result := schema.Compile(parse(t, s), draft06.Version)
+	if !result.Valid() {
+		t.Fatalf("compile %s: %v", s, result.Error())
+	}
+	return sc
+}
@@ -0,0 +1,19 @@
+package draft06
+
+import (
+	schema "github.com/eachain/jsonschema"
+	"github.com/eachain/jsonschema/basic"
+)
+
+// FormatOf extends the draft-04 formats with those added in draft-06.
+var FormatOf = basic.FormatOf{
+	"date-time":     schema.ValidateFunc(basic.DateTimeFormatValidator),
+	"email":         schema.ValidateFunc(basic.EmailFormatValidator),
+	"hostname":      schema.ValidateFunc(basic.HostnameFormatValidator),
+	"ipv4":          schema.ValidateFunc(basic.IPv4FormatValidator),
+	"ipv6":          schema.ValidateFunc(basic.IPv6FormatValidator),
+	"uri":           schema.ValidateFunc(basic.URIFormatValidator),
+	"uri-reference": schema.ValidateFunc(basic.URIReferenceFormatValidator),
+	"json-pointer":  schema.ValidateFunc(basic.JSONPointerFormatValidator),
+	"regex":         schema.ValidateFunc(basic.RegexFormatValidator),
+}
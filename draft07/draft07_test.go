@@ -0,0 +1,80 @@
+package draft07_test
+
+import (
+	"testing"
+
+	schema "github.com/eachain/jsonschema"
+	"github.com/eachain/jsonschema/draft07"
+	"github.com/eachain/jsonschema/jsi"
+)
+
+func TestKeywords(t *testing.T) {
+	ifThenElse := `{
+		"if": {"properties": {"kind": {"const": "a"}}},
+		"then": {"required": ["a"]},
+		"else": {"required": ["b"]}
+	}`
+	tests := []struct {
+		schema   string
+		instance string
+		valid    bool
+	}{
+		{ifThenElse, `{"kind": "a", "a": 1}`, true},
+		{ifThenElse, `{"kind": "a", "b": 1}`, false},
+		{ifThenElse, `{"kind": "x", "b": 1}`, true},
+		{ifThenElse, `{"kind": "x", "a": 1}`, false},
+		{`{"if": {"type": "string"}}`, `1`, true},
+		{`{"then": false}`, `1`, true}, // then without if is ignored
+		{`{"else": false}`, `1`, true},
+		{`{"if": true, "then": {"minimum": 2}}`, `1`, false},
+		{`{"if": false, "else": {"minimum": 2}}`, `1`, false},
+		{`{"readOnly": true, "writeOnly": false, "type": "string"}`, `"a"`, true},
+		{`{"$comment": "a comment", "type": "string"}`, `"a"`, true},
+		{`{"format": "date"}`, `"2020-01-31"`, true},
+		{`{"format": "date"}`, `"2020-13-01"`, false},
+		{`{"format": "time"}`, `"12:30:00+08:00"`, true},
+		{`{"format": "time"}`, `"25:00:00+08:00"`, false},
+		{`{"format": "relative-json-pointer"}`, `"1/a"`, true},
+		{`{"format": "relative-json-pointer"}`, `"/a"`, false},
+		{`{"format": "iri"}`, `"http://example.com/ä"`, true},
+	}
+	for _, tt := range tests {
+		sc := compile(t, tt.schema)
+		result := sc.Validate(parse(t, tt.instance))
+		if result.Valid() != tt.valid {
+			t.Errorf("%v with %v: got valid %v, want %v: %v", tt.schema, tt.instance,
+				result.Valid(), tt.valid, result.Error())
+		}
+	}
+}
+
+func TestInvalidKeywords(t *testing.T) {
+	for _, s := range []string{
+		`{"if": 1}`,
+		`{"readOnly": "yes"}`,
+		`{"$comment": 1}`,
+		`{"contentMediaType": 1}`,
+	} {
+		if _, result := schema.Compile(parse(t, s), draft07.Version); result.Valid() {
+			t.Errorf("%v compiled", s)
+		}
+	}
+}
+
+func parse(t *testing.T, s string) jsi.JSON {
+	t.Helper()
+	js, err := jsi.NewBytesParser([]byte(s)).Parse()
+	if err != nil {
+		t.Fatalf("parse %s: %v", s, err)
+	}
+	return js
+}
+
+func compile(t *testing.T, s string) *schema.Schema {
+	t.Helper()
+	sc, result := schema.Compile(parse(t, s), draft07.Version)
+	if !result.Valid() {
+		t.Fatalf("compile %s: %v", s, result.Error())
+	}
+	return sc
+}
@@ -0,0 +1,101 @@
+package draft201909_test
+
+import (
+	"testing"
+
+	schema "github.com/eachain/jsonschema"
+	"github.com/eachain/jsonschema/draft201909"
+	"github.com/eachain/jsonschema/jsi"
+)
+
+func TestKeywords(t *testing.T) {
+	tree := `{
+		"$recursiveAnchor": true,
+		"type": "object",
+		"properties": {"children": {"type": "array", "items": {"$recursiveRef": "#"}}}
+	}`
+	extended := `{
+		"$id": "https://example.com/strict",
+		"$recursiveAnchor": true,
+		"$ref": "tree",
+		"unevaluatedProperties": false,
+		"$defs": {"tree": {
+			"$id": "tree",
+			"$recursiveAnchor": true,
+			"type": "object",
+			"properties": {"data": true, "children": {"type": "array", "items": {"$recursiveRef": "#"}}}
+		}}
+	}`
+	unevaluated := `{
+		"properties": {"a": true},
+		"allOf": [{"properties": {"b": true}}],
+		"unevaluatedProperties": false
+	}`
+	tests := []struct {
+		schema   string
+		instance string
+		valid    bool
+	}{
+		{tree, `{"children": [{"children": []}]}`, true},
+		{tree, `{"children": [{"children": 1}]}`, false},
+		{extended, `{"data": 1, "children": [{"data": 2}]}`, true},
+		// $recursiveRef of tree refers to the strict schema from it
+		{extended, `{"children": [{"other": 2}]}`, false},
+		{unevaluated, `{"a": 1, "b": 2}`, true},
+		{unevaluated, `{"a": 1, "c": 2}`, false},
+		{`{"unevaluatedProperties": {"type": "string"}}`, `{"x": "a"}`, true},
+		{`{"unevaluatedProperties": {"type": "string"}}`, `{"x": 1}`, false},
+		// members evaluated by if count without then and else
+		{`{"if": {"properties": {"a": {"const": 1}}}, "unevaluatedProperties": false}`, `{"a": 1}`, true},
+		{`{"if": {"properties": {"a": {"const": 1}}}, "unevaluatedProperties": false}`, `{"a": 2}`, false},
+		{`{"items": [true], "unevaluatedItems": false}`, `[1]`, true},
+		{`{"items": [true], "unevaluatedItems": false}`, `[1, 2]`, false},
+		{`{"items": [true], "additionalItems": true, "unevaluatedItems": false}`, `[1, 2]`, true},
+		{`{"$ref": "#/$defs/a", "$defs": {"a": {"type": "integer"}}}`, `1`, true},
+		{`{"$ref": "#/$defs/a", "$defs": {"a": {"type": "integer"}}}`, `"a"`, false},
+		{`{"dependentRequired": {"a": ["b"]}}`, `{"a": 1}`, false},
+		{`{"dependentSchemas": {"a": {"required": ["b"]}}}`, `{"a": 1, "b": 2}`, true},
+		{`{"contains": {"type": "integer"}, "minContains": 2}`, `[1, "a"]`, false},
+		{`{"contains": {"type": "integer"}, "maxContains": 1}`, `[1, 2]`, false},
+		{`{"contains": {"type": "integer"}, "minContains": 0}`, `[]`, true},
+	}
+	for _, tt := range tests {
+		sc := compile(t, tt.schema)
+		result := sc.Validate(parse(t, tt.instance))
+		if result.Valid() != tt.valid {
+			t.Errorf("%v with %v: got valid %v, want %v: %v", tt.schema, tt.instance,
+				result.Valid(), tt.valid, result.Error())
+		}
+	}
+}
+
+func TestInvalidKeywords(t *testing.T) {
+	for _, s := range []string{
+		`{"$defs": 1}`,
+		`{"$recursiveRef": 1}`,
+		`{"unevaluatedProperties": 1}`,
+		`{"minContains": -1}`,
+	} {
+		if _, result := schema.Compile(parse(t, s), draft201909.Version); result.Valid() {
+			t.Errorf("%v compiled", s)
+		}
+	}
+}
+
+func parse(t *testing.T, s string) jsi.JSON {
+	t.Helper()
+	js, err := jsi.NewBytesParser([]byte(s)).Parse()
+	if err != nil {
+		t.Fatalf("parse %s: %v", s, err)
+	}
+	return js
+}
+
+func compile(t *testing.T, s string) *schema.Schema {
+	t.Helper()
+	sc, result := schema.Compile(parse(t, s), draft201909.Version)
+	if !result.Valid() {
+		t.Fatalf("compile %s: %v", s, result.Error())
+	}
+	return sc
+}
@@ -0,0 +1,27 @@
+package draft201909
+
+import (
+	schema "github.com/eachain/jsonschema"
+	"github.com/eachain/jsonschema/basic"
+)
+
+// FormatOf extends the draft-07 formats with those added in draft 2019-09.
+var FormatOf = basic.FormatOf{
+	"date-time":             schema.ValidateFunc(basic.DateTimeFormatValidator),
+	"date":                  schema.ValidateFunc(basic.DateFormatValidator),
+	"time":                  schema.ValidateFunc(basic.TimeFormatValidator),
+	"email":                 schema.ValidateFunc(basic.EmailFormatValidator),
+	"idn-email":             schema.ValidateFunc(basic.EmailFormatValidator),
+	"hostname":              schema.ValidateFunc(basic.HostnameFormatValidator),
+	"ipv4":                  schema.ValidateFunc(basic.IPv4FormatValidator),
+	"ipv6":                  schema.ValidateFunc(basic.IPv6FormatValidator),
+	"uri":                   schema.ValidateFunc(basic.URIFormatValidator),
+	"uri-reference":         schema.ValidateFunc(basic.URIReferenceFormatValidator),
+	"iri":                   schema.ValidateFunc(basic.URIFormatValidator),
+	"iri-reference":         schema.ValidateFunc(basic.URIReferenceFormatValidator),
+	"json-pointer":          schema.ValidateFunc(basic.JSONPointerFormatValidator),
+	"relative-json-pointer": schema.ValidateFunc(basic.RelativeJSONPointerFormatValidator),
+	"regex":                 schema.ValidateFunc(basic.RegexFormatValidator),
+	"uuid":                  schema.ValidateFunc(basic.UUIDFormatValidator),
+	"duration":              schema.ValidateFunc(basic.DurationFormatValidator),
+}
@@ -0,0 +1,109 @@
+package draft202012_test
+
+import (
+	"strings"
+	"testing"
+
+	schema "github.com/eachain/jsonschema"
+	"github.com/eachain/jsonschema/draft202012"
+	"github.com/eachain/jsonschema/jsi"
+)
+
+func TestKeywords(t *testing.T) {
+	list := `{
+		"$dynamicAnchor": "node",
+		"type": "object",
+		"properties": {"next": {"$dynamicRef": "#node"}}
+	}`
+	extended := `{
+		"$id": "https://example.com/strict",
+		"$dynamicAnchor": "node",
+		"$ref": "list",
+		"unevaluatedProperties": false,
+		"$defs": {"list": {
+			"$id": "list",
+			"$dynamicAnchor": "node",
+			"type": "object",
+			"properties": {"value": true, "next": {"$dynamicRef": "#node"}}
+		}}
+	}`
+	tests := []struct {
+		schema   string
+		instance string
+		valid    bool
+	}{
+		{`{"prefixItems": [{"type": "integer"}, {"type": "string"}]}`, `[1, "a", null]`, true},
+		{`{"prefixItems": [{"type": "integer"}, {"type": "string"}]}`, `[1, 2]`, false},
+		{`{"prefixItems": [{"type": "integer"}], "items": false}`, `[1]`, true},
+		{`{"prefixItems": [{"type": "integer"}], "items": false}`, `[1, 2]`, false},
+		{`{"items": {"type": "integer"}}`, `[1, "a"]`, false},
+		{`{"prefixItems": [true], "unevaluatedItems": false}`, `[1, 2]`, false},
+		{`{"contains": {"type": "integer"}, "unevaluatedItems": false}`, `[1, 2]`, true},
+		{list, `{"next": {"next": {}}}`, true},
+		{list, `{"next": {"next": 1}}`, false},
+		{extended, `{"value": 1, "next": {"value": 2}}`, true},
+		// $dynamicRef of list refers to the strict schema from it
+		{extended, `{"next": {"other": 2}}`, false},
+		{`{"$ref": "#a", "$defs": {"a": {"$anchor": "a", "type": "string"}}}`, `1`, false},
+	}
+	for _, tt := range tests {
+		sc := compile(t, tt.schema)
+		result := sc.Validate(parse(t, tt.instance))
+		if result.Valid() != tt.valid {
+			t.Errorf("%v with %v: got valid %v, want %v: %v", tt.schema, tt.instance,
+				result.Valid(), tt.valid, result.Error())
+		}
+	}
+}
+
+func TestVocabulary(t *testing.T) {
+	compile(t, `{"$vocabulary": {"https://json-schema.org/draft/2020-12/vocab/core": true}}`)
+
+	_, result := schema.Compile(parse(t, `{"$vocabulary": {"https://example.com/vocab": true}}`), draft202012.Version)
+	if result.Valid() || !strings.Contains(result.Error(), "vocabulary required but not supported") {
+		t.Errorf("unknown required vocabulary: %v", result.Error())
+	}
+
+	// optional vocabularies unknown are only warned
+	_, result = schema.Compile(parse(t, `{"$vocabulary": {"https://example.com/vocab": false}}`), draft202012.Version)
+	if !result.Valid() || len(result.Warnings) != 1 ||
+		!strings.Contains(result.Warnings[0].Msg, "vocabulary not supported") {
+		t.Errorf("unknown optional vocabulary: %v %v", result.Error(), result.Warnings)
+	}
+
+	_, result = schema.Compile(parse(t, `{"$vocabulary": {"https://example.com/vocab": 1}}`), draft202012.Version)
+	if result.Valid() {
+		t.Errorf("vocabulary not boolean compiled")
+	}
+}
+
+func TestInvalidKeywords(t *testing.T) {
+	for _, s := range []string{
+		`{"prefixItems": {}}`,
+		`{"$dynamicRef": 1}`,
+		`{"$vocabulary": []}`,
+		`{"$defs": {"a": 1}}`,
+	} {
+		if _, result := schema.Compile(parse(t, s), draft202012.Version); result.Valid() {
+			t.Errorf("%v compiled", s)
+		}
+	}
+}
+
+func parse(t *testing.T, s string) jsi.JSON {
+	t.Helper()
+	js, err := jsi.NewBytesParser([]byte(s)).Parse()
+	if err != nil {
+		t.Fatalf("parse %s: %v", s, err)
+	}
+	return js
+}
+
+func compile(t *testing.T, s string) *schema.Schema {
+	t.Helper()
+	sc, result := schema.Compile(parse(t, s), draft202012.Version)
+	if !result.Valid() {
+		t.Fatalf("compile %s: %v", s, result.Error())
+	}
+	return sc
+}
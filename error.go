@@ -3,6 +3,8 @@ package jsonschema
 import (
 	"fmt"
 	"strings"
+
+	"github.com/eachain/jsonschema/jsi"
 )
 
 type Type = string
@@ -30,6 +32,7 @@ var AllTypes = []Type{
 var (
 	MsgNoDraftRegister      = "no draft register"
 	MsgRootCompilerNotFound = "root compiler not found"
+	MsgMetaSchemaNotFound   = "meta-schema not found"
 )
 
 type Error struct {
@@ -37,6 +40,21 @@ type Error struct {
 	Type  Type
 	Value interface{}
 	Msg   string
+
+	// stable code of the error with its typed params,
+	// Msg can be rendered by a Catalog with them
+	Code   Code
+	Params Params
+
+	// the failed keyword and its locations at validation time,
+	// eg. "maxLength" at "#/properties/name/maxLength"
+	Keyword                 string
+	KeywordLocation         string
+	AbsoluteKeywordLocation string
+
+	// errors of subschemas failing the keyword,
+	// eg. of every branch of anyOf, see BestMatch
+	Causes []Error
 }
 
 func (e Error) Error() string {
@@ -45,16 +63,29 @@ func (e Error) Error() string {
 	}
 
 	val := fmt.Sprint(e.Value)
-	if val == "" {
+	if e.Value == nil || val == "" {
 		return fmt.Sprintf("path %v type '%v': %v", e.Field, e.Type, e.Msg)
 	}
 	return fmt.Sprintf("path %v type '%v': %v, value: %v",
 		e.Field, e.Type, e.Msg, e.Value)
 }
 
+// Position returns where Value is in its source, eg. "config.json:12:5",
+// which is known only if Value is parsed by a jsi reader parser.
+func (e Error) Position() jsi.Position {
+	if js, ok := e.Value.(jsi.JSON); ok {
+		return jsi.PositionOf(js)
+	}
+	return jsi.Position{}
+}
+
 type Result struct {
-	Warnings []Error
-	Errors   []Error
+	Warnings    []Error
+	Errors      []Error
+	Annotations []Annotation // collected only if validate WithAnnotations
+	Coercions   []Coercion   // values converted if validate WithCoerceTypes
+
+	evaluated map[string]*Evaluated
 }
 
 func (r *Result) Valid() bool {
@@ -133,5 +164,33 @@ func (r *Result) Merge(t *Result) *Result {
 			r.Errors = append(r.Errors, t.Errors...)
 		}
 	}
+
+	if len(r.Annotations) == 0 {
+		r.Annotations = t.Annotations
+	} else {
+		if len(t.Annotations) > 0 {
+			r.Annotations = append(r.Annotations, t.Annotations...)
+		}
+	}
+
+	if len(r.Coercions) == 0 {
+		r.Coercions = t.Coercions
+	} else {
+		if len(t.Coercions) > 0 {
+			r.Coercions = append(r.Coercions, t.Coercions...)
+		}
+	}
+
+	if r.evaluated == nil {
+		r.evaluated = t.evaluated
+	} else {
+		for field, e := range t.evaluated {
+			if o := r.evaluated[field]; o != nil {
+				o.merge(e)
+			} else {
+				r.evaluated[field] = e
+			}
+		}
+	}
 	return r
 }
@@ -0,0 +1,65 @@
+package jsonschema_test
+
+import (
+	"reflect"
+	"testing"
+)
+
+const errorSchema = `{
+	"$id": "https://example.com/s",
+	"required": ["x"],
+	"properties": {
+		"a": {"type": "string", "maxLength": 2},
+		"b": {"$ref": "#/definitions/n"},
+		"c": {"anyOf": [{"type": "string"}, {"minimum": 3}]},
+		"d": {"uniqueItems": true, "items": {"enum": [1, 2]}}
+	},
+	"definitions": {"n": {"minimum": 1}}
+}`
+
+const errorInstance = `{"a": "xyz", "b": 0, "c": 1, "d": [1, 1, 3]}`
+
+func TestErrorLocations(t *testing.T) {
+	sc := compile(t, errorSchema, "draft-07")
+	result := sc.Validate(parse(t, errorInstance))
+
+	type location struct {
+		field, keyword, keywordLocation, absoluteKeywordLocation string
+	}
+	var got []location
+	for _, e := range result.Errors {
+		got = append(got, location{e.Field, e.Keyword, e.KeywordLocation, e.AbsoluteKeywordLocation})
+	}
+	want := []location{
+		{"#", "required", "#/required", "https://example.com/s#/required"},
+		{"#/a", "maxLength", "#/properties/a/maxLength", "https://example.com/s#/properties/a/maxLength"},
+		// through a reference, the absolute location is in the target
+		{"#/b", "minimum", "#/properties/b/$ref/minimum", "https://example.com/s#/definitions/n/minimum"},
+		{"#/c", "anyOf", "#/properties/c/anyOf", "https://example.com/s#/properties/c/anyOf"},
+		{"#/d", "uniqueItems", "#/properties/d/uniqueItems", "https://example.com/s#/properties/d/uniqueItems"},
+		{"#/d/2", "enum", "#/properties/d/items/enum", "https://example.com/s#/properties/d/items/enum"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v\nwant %v", got, want)
+	}
+
+	// causes of anyOf are located in its branches
+	causes := result.Errors[3].Causes
+	if len(causes) != 2 ||
+		causes[0].KeywordLocation != "#/properties/c/anyOf/0/type" ||
+		causes[1].KeywordLocation != "#/properties/c/anyOf/1/minimum" {
+		t.Errorf("causes of anyOf: %v", causes)
+	}
+}
+
+func TestErrorLocationsWithoutID(t *testing.T) {
+	sc := compile(t, `{"items": {"minimum": 1}}`, "draft-07")
+	result := sc.Validate(parse(t, `[1, 0]`))
+	if len(result.Errors) != 1 {
+		t.Fatalf("errors: %v", result.Error())
+	}
+	e := result.Errors[0]
+	if e.Field != "#/1" || e.KeywordLocation != "#/items/minimum" || e.AbsoluteKeywordLocation != "#/items/minimum" {
+		t.Errorf("got %+v", e)
+	}
+}
@@ -0,0 +1,108 @@
+package jsonschema
+
+// Evaluated records which properties and items of an instance location
+// have been evaluated by keywords, used by unevaluatedProperties
+// and unevaluatedItems.
+type Evaluated struct {
+	Properties map[string]bool
+	Items      int          // items before index Items are evaluated
+	Indexes    map[int]bool // items evaluated one by one, eg. by contains
+}
+
+func (e *Evaluated) Property(key string) bool {
+	return e != nil && e.Properties[key]
+}
+
+func (e *Evaluated) Item(i int) bool {
+	return e != nil && (i < e.Items || e.Indexes[i])
+}
+
+func (e *Evaluated) merge(t *Evaluated) {
+	for key := range t.Properties {
+		if e.Properties == nil {
+			e.Properties = make(map[string]bool)
+		}
+		e.Properties[key] = true
+	}
+	if t.Items > e.Items {
+		e.Items = t.Items
+	}
+	for i := range t.Indexes {
+		if e.Indexes == nil {
+			e.Indexes = make(map[int]bool)
+		}
+		e.Indexes[i] = true
+	}
+}
+
+func (r *Result) evaluatedOf(field string) *Evaluated {
+	if r.evaluated == nil {
+		r.evaluated = make(map[string]*Evaluated)
+	}
+	e := r.evaluated[field]
+	if e == nil {
+		e = new(Evaluated)
+		r.evaluated[field] = e
+	}
+	return e
+}
+
+// EvaluateProperty marks property key of instance field evaluated.
+func (r *Result) EvaluateProperty(field, key string) *Result {
+	if r == nil {
+		r = new(Result)
+	}
+	e := r.evaluatedOf(field)
+	if e.Properties == nil {
+		e.Properties = make(map[string]bool)
+	}
+	e.Properties[key] = true
+	return r
+}
+
+// EvaluateItems marks the first n items of instance field evaluated.
+func (r *Result) EvaluateItems(field string, n int) *Result {
+	if r == nil {
+		r = new(Result)
+	}
+	e := r.evaluatedOf(field)
+	if n > e.Items {
+		e.Items = n
+	}
+	return r
+}
+
+// EvaluateItem marks item i of instance field evaluated.
+func (r *Result) EvaluateItem(field string, i int) *Result {
+	if r == nil {
+		r = new(Result)
+	}
+	e := r.evaluatedOf(field)
+	if e.Indexes == nil {
+		e.Indexes = make(map[int]bool)
+	}
+	e.Indexes[i] = true
+	return r
+}
+
+// Evaluated returns what has been evaluated of instance field, nil if nothing.
+func (r *Result) Evaluated(field string) *Evaluated {
+	if r == nil {
+		return nil
+	}
+	return r.evaluated[field]
+}
+
+// KeepEvaluated drops evaluated records of any instance location but field,
+// they are of no use once the subschema at field has been validated.
+func (r *Result) KeepEvaluated(field string) *Result {
+	if r == nil || r.evaluated == nil {
+		return r
+	}
+	e := r.evaluated[field]
+	r.evaluated = nil
+	if e != nil {
+		r.evaluated = map[string]*Evaluated{field: e}
+	}
+	return r
+}
@@ -0,0 +1,242 @@
+// Package gogen generates Go types from JSON Schemas, so structs decoding
+// documents valid against a schema don't drift apart from it.
+//
+// Objects with properties become structs with json tags, optional
+// properties become pointer fields with omitempty, schemas in definitions
+// and targets of references become named types, and enums of strings or
+// numbers become named types with constants. References are resolved as
+// the compiled schema resolves them, $recursiveRef and $dynamicRef to the
+// schemas they refer to without dynamic scope. Titles and descriptions
+// become doc comments. Schemas which don't map to a single Go type, like
+// anyOf of different types, become interface{}.
+package gogen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+
+	schema "github.com/eachain/jsonschema"
+	"github.com/eachain/jsonschema/jsi"
+)
+
+// Options of Generate, all of them are optional.
+type Options struct {
+	Package string // package name of the source, "schema" if empty
+	Name    string // type name of the root schema, "Root" if empty
+
+	// URI of the schema document, references are resolved against it.
+	URI string
+	// Loader loads referenced schema documents, schema.DefaultLoader if nil.
+	Loader schema.Loader
+	// Draft compiles the schema as draft, eg. "draft-07", detected if empty.
+	Draft string
+}
+
+// Generate compiles schema js and returns formatted Go source declaring
+// types of it. The schema must be valid, drafts of it must be registered
+// by importing draft packages.
+func Generate(js jsi.JSON, opts Options) ([]byte, error) {
+	if opts.Package == "" {
+		opts.Package = "schema"
+	}
+	if opts.Name == "" {
+		opts.Name = "Root"
+	}
+	loader := opts.Loader
+	if loader == nil {
+		loader = schema.DefaultLoader
+	}
+
+	reg := schema.NewRegistry()
+	reg.Loader = loader
+	var drafts []string
+	if opts.Draft != "" {
+		drafts = append(drafts, opts.Draft)
+	}
+	var sc *schema.Schema
+	var result *schema.Result
+	if opts.URI != "" {
+		if err := reg.AddResource(opts.URI, js); err != nil {
+			return nil, err
+		}
+		sc, result = reg.CompileURI(opts.URI, drafts...)
+	} else {
+		sc, result = reg.Compile(js, drafts...)
+	}
+	if !result.Valid() {
+		return nil, fmt.Errorf("invalid schema: %v", result.Errors[0].Error())
+	}
+
+	g := newGenerator(sc)
+	if _, err := g.declare(js, opts.Name); err != nil {
+		return nil, err
+	}
+	if js.Type() == jsi.TypeObject {
+		for _, kw := range []string{"definitions", "$defs"} {
+			defs, ok := js.(jsi.Object).Index(kw).(jsi.Object)
+			if !ok {
+				continue
+			}
+			iter := defs.Iter()
+			for iter.Next() {
+				key, def := iter.Entry()
+				if _, err := g.declare(def, typeName(key)); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return g.source(opts.Package)
+}
+
+type generator struct {
+	schema *schema.Schema // compiled, references are resolved by it
+
+	types   map[jsi.JSON]*namedType
+	names   map[string]bool
+	decls   []*namedType
+	imports map[string]bool
+}
+
+// namedType is a type declared for a schema.
+type namedType struct {
+	name     string
+	js       jsi.JSON
+	expr     string   // underlying type
+	consts   []string // constant specs of enum
+	isStruct bool
+	done     bool // expr is generated
+}
+
+func newGenerator(sc *schema.Schema) *generator {
+	return &generator{
+		schema:  sc,
+		types:   make(map[jsi.JSON]*namedType),
+		names:   make(map[string]bool),
+		imports: make(map[string]bool),
+	}
+}
+
+// declare declares a named type for schema js, or returns the one declared.
+func (g *generator) declare(js jsi.JSON, name string) (*namedType, error) {
+	if nt := g.types[js]; nt != nil {
+		return nt, nil
+	}
+	nt := &namedType{name: g.uniqueName(name), js: js, isStruct: g.isStruct(js)}
+	g.types[js] = nt
+	g.decls = append(g.decls, nt)
+
+	var err error
+	if nt.consts = g.enum(js, nt.name); nt.consts != nil {
+		nt.expr = enumType(js)
+	} else if nt.isStruct {
+		nt.expr, err = g.structOf(js.(jsi.Object), nt.name)
+	} else {
+		var t goType
+		t, err = g.typeOf(js, nt.name, false)
+		nt.expr = t.expr
+	}
+	nt.done = true
+	return nt, err
+}
+
+func (g *generator) uniqueName(name string) string {
+	unique := name
+	for i := 2; g.names[unique]; i++ {
+		unique = fmt.Sprintf("%v%d", name, i)
+	}
+	g.names[unique] = true
+	return unique
+}
+
+func (g *generator) source(pkg string) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by gogen from JSON Schema; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %v\n", pkg)
+	if len(g.imports) > 0 {
+		var imports []string
+		for path := range g.imports {
+			imports = append(imports, path)
+		}
+		sort.Strings(imports)
+		b.WriteString("\nimport (\n")
+		for _, path := range imports {
+			fmt.Fprintf(&b, "\t%q\n", path)
+		}
+		b.WriteString(")\n")
+	}
+
+	for _, nt := range g.decls {
+		b.WriteByte('\n')
+		writeDoc(&b, "", nt.name, nt.js)
+		fmt.Fprintf(&b, "type %v %v\n", nt.name, nt.expr)
+		if len(nt.consts) > 0 {
+			b.WriteString("\nconst (\n")
+			for _, c := range nt.consts {
+				fmt.Fprintf(&b, "\t%v\n", c)
+			}
+			b.WriteString(")\n")
+		}
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated source: %w", err)
+	}
+	return src, nil
+}
+
+// writeDoc writes title and description of js as a doc comment, which
+// starts with name if it is not empty, as doc comments of types do.
+func writeDoc(b *bytes.Buffer, indent, name string, js jsi.JSON) {
+	title, desc := stringOf(js, "title"), stringOf(js, "description")
+	var lines []string
+	if title != "" {
+		lines = append(lines, strings.Split(title, "\n")...)
+	}
+	if desc != "" {
+		if title != "" {
+			lines = append(lines, "")
+		}
+		lines = append(lines, strings.Split(desc, "\n")...)
+	}
+	if name != "" && len(lines) > 0 && lines[0] != name && !strings.HasPrefix(lines[0], name+" ") {
+		lines[0] = name + " is " + lowerFirst(lines[0])
+	}
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" {
+			fmt.Fprintf(b, "%v//\n", indent)
+		} else {
+			fmt.Fprintf(b, "%v// %v\n", indent, line)
+		}
+	}
+}
+
+// lowerFirst returns s with the first letter lower case, unless it
+// starts an initialism like "ID" or "URL".
+func lowerFirst(s string) string {
+	rs := []rune(s)
+	if len(rs) > 1 && unicode.IsUpper(rs[1]) {
+		return s
+	}
+	rs[0] = unicode.ToLower(rs[0])
+	return string(rs)
+}
+
+// stringOf returns the string member key of schema js, or "".
+func stringOf(js jsi.JSON, key string) string {
+	obj, ok := js.(jsi.Object)
+	if !ok {
+		return ""
+	}
+	s, ok := obj.Index(key).(jsi.String)
+	if !ok {
+		return ""
+	}
+	return s.Value()
+}
@@ -0,0 +1,173 @@
+package gogen_test
+
+import (
+	"go/parser"
+	"go/token"
+	"regexp"
+	"strings"
+	"testing"
+
+	schema "github.com/eachain/jsonschema"
+	_ "github.com/eachain/jsonschema/draft07"
+	_ "github.com/eachain/jsonschema/draft201909"
+	_ "github.com/eachain/jsonschema/draft202012"
+	"github.com/eachain/jsonschema/gogen"
+	"github.com/eachain/jsonschema/jsi"
+)
+
+func generate(t *testing.T, s string, opts gogen.Options) string {
+	t.Helper()
+	js, err := jsi.NewBytesParser([]byte(s)).Parse()
+	if err != nil {
+		t.Fatalf("parse %v: %v", s, err)
+	}
+	if opts.Loader == nil {
+		opts.Loader = schema.NoLoader
+	}
+	src, err := gogen.Generate(js, opts)
+	if err != nil {
+		t.Fatalf("generate %v: %v", s, err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "gen.go", src, 0); err != nil {
+		t.Fatalf("generated source: %v\n%s", err, src)
+	}
+	return string(src)
+}
+
+var blanks = regexp.MustCompile(`[ \t]+`)
+
+// contains checks src contains want, spaces aligning fields are ignored.
+func contains(t *testing.T, src string, want ...string) {
+	t.Helper()
+	for _, w := range want {
+		if !strings.Contains(blanks.ReplaceAllString(src, " "), blanks.ReplaceAllString(w, " ")) {
+			t.Errorf("%q not generated:\n%v", w, src)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	src := generate(t, `{
+		"type": "object",
+		"properties": {
+			"id": {"type": "integer"},
+			"name": {"type": "string"},
+			"tags": {"type": "array", "items": {"type": "string"}},
+			"status": {"enum": ["new", "done"]},
+			"created": {"type": "string", "format": "date-time"}
+		},
+		"required": ["id"]
+	}`, gogen.Options{Package: "api", Name: "Task"})
+	contains(t, src,
+		"package api",
+		`"time"`,
+		"type Task struct",
+		"ID int64 `json:\"id\"`",
+		"Name *string `json:\"name,omitempty\"`",
+		"Tags []string `json:\"tags,omitempty\"`",
+		"Status *TaskStatus `json:\"status,omitempty\"`",
+		"Created *time.Time",
+		"type TaskStatus string",
+		`TaskStatusNew TaskStatus = "new"`,
+	)
+}
+
+func TestGenerateDocComments(t *testing.T) {
+	src := generate(t, `{
+		"title": "Order",
+		"description": "An order placed.",
+		"type": "object",
+		"properties": {
+			"item": {"$ref": "#/definitions/item"},
+			"url": {"$ref": "#/definitions/url"}
+		},
+		"definitions": {
+			"item": {"title": "Item of an order", "type": "object", "properties": {"n": {"type": "integer"}}},
+			"url": {"title": "Address of it", "type": "string"}
+		}
+	}`, gogen.Options{})
+	// doc comments of types start with the type names
+	contains(t, src,
+		"// Root is order\n//\n// An order placed.\ntype Root struct",
+		"// Item of an order\ntype Item struct",
+		"// URL is address of it\ntype URL string",
+	)
+}
+
+func TestGenerateRefs(t *testing.T) {
+	loads := 0
+	loader := schema.LoaderFunc(func(uri string) (jsi.JSON, error) {
+		loads++
+		return schema.MemoryLoader{
+			"https://example.com/other.json": []byte(`{
+				"type": "object",
+				"properties": {"x": {"$ref": "#/definitions/a"}},
+				"definitions": {"a": {"type": "integer"}}
+			}`),
+		}.Load(uri)
+	})
+	src := generate(t, `{
+		"type": "object",
+		"properties": {
+			"a": {"$ref": "other.json#/definitions/a"},
+			"b": {"$ref": "other.json"},
+			"c": {"$ref": "#c"}
+		},
+		"required": ["b"],
+		"definitions": {"c": {"$id": "#c", "type": "boolean"}}
+	}`, gogen.Options{URI: "https://example.com/root.json", Loader: loader})
+	contains(t, src,
+		"A *A `json:\"a,omitempty\"`",
+		"B Other `json:\"b\"`",
+		"C *C `json:\"c,omitempty\"`",
+		"type A int64",
+		"X *A `json:\"x,omitempty\"`",
+		"type C bool",
+	)
+	if loads != 1 {
+		t.Errorf("other.json loaded %d times", loads)
+	}
+}
+
+func TestGenerateDynamicRefs(t *testing.T) {
+	src := generate(t, `{
+		"$schema": "https://json-schema.org/draft/2019-09/schema",
+		"$recursiveAnchor": true,
+		"type": "object",
+		"properties": {"children": {"type": "array", "items": {"$recursiveRef": "#"}}}
+	}`, gogen.Options{Name: "Tree"})
+	contains(t, src, "Children []Tree `json:\"children,omitempty\"`")
+
+	src = generate(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$dynamicAnchor": "node",
+		"type": "object",
+		"properties": {"next": {"$dynamicRef": "#node"}}
+	}`, gogen.Options{Name: "List"})
+	contains(t, src, "Next *List `json:\"next,omitempty\"`")
+}
+
+func TestGenerateAllOf(t *testing.T) {
+	src := generate(t, `{
+		"allOf": [
+			{"$ref": "#/$defs/base"},
+			{"properties": {"extra": {"type": "number"}}}
+		],
+		"properties": {"own": {"type": ["string", "null"]}},
+		"required": ["own"],
+		"$defs": {"base": {"type": "object", "properties": {"id": {"type": "string"}}}}
+	}`, gogen.Options{})
+	contains(t, src,
+		"type Root struct {\n\tBase\n",
+		"Extra *float64",
+		"Own *string `json:\"own\"`",
+		"type Base struct",
+	)
+}
+
+func TestGenerateInvalid(t *testing.T) {
+	js, _ := jsi.NewBytesParser([]byte(`{"properties": {"a": {"$ref": "#/definitions/none"}}}`)).Parse()
+	if _, err := gogen.Generate(js, gogen.Options{Loader: schema.NoLoader}); err == nil {
+		t.Errorf("unresolved reference generated")
+	}
+}
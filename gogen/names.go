@@ -0,0 +1,78 @@
+package gogen
+
+import (
+	"path"
+	"strings"
+	"unicode"
+)
+
+// initialisms are words kept upper case in Go names.
+var initialisms = map[string]bool{
+	"API": true, "CPU": true, "CSS": true, "DNS": true, "HTML": true,
+	"HTTP": true, "HTTPS": true, "ID": true, "IP": true, "JSON": true,
+	"SQL": true, "TCP": true, "TLS": true, "TTL": true, "UDP": true,
+	"UI": true, "URI": true, "URL": true, "UUID": true, "XML": true,
+}
+
+// camelCase joins words of s in camel case, with the first letter upper
+// case, eg. "user_id" is "UserID". Characters other than letters and
+// digits separate words.
+func camelCase(s string) string {
+	var b strings.Builder
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, w := range words {
+		if initialisms[strings.ToUpper(w)] {
+			b.WriteString(strings.ToUpper(w))
+			continue
+		}
+		rs := []rune(w)
+		rs[0] = unicode.ToUpper(rs[0])
+		b.WriteString(string(rs))
+	}
+	return b.String()
+}
+
+// fieldName returns the exported Go name of property key.
+func fieldName(key string) string {
+	name := camelCase(key)
+	if name == "" {
+		return "Field"
+	}
+	if !unicode.IsLetter([]rune(name)[0]) {
+		return "X" + name
+	}
+	return name
+}
+
+// typeName returns the exported Go name of a type named key.
+func typeName(key string) string {
+	name := fieldName(key)
+	if name == "Field" {
+		return "Type"
+	}
+	return name
+}
+
+// refName returns the type name of the schema ref refers to, by the last
+// segment of the JSON pointer or the document name, or fallback.
+func refName(ref, fallback string) string {
+	i := strings.IndexByte(ref, '#')
+	if i < 0 {
+		i = len(ref)
+	}
+	if frag := strings.TrimRight(ref[i:], "/#"); frag != "" {
+		last := frag[strings.LastIndexAny(frag, "/#")+1:]
+		last = strings.NewReplacer("~1", "/", "~0", "~").Replace(last)
+		if name := typeName(last); name != "Type" {
+			return name
+		}
+	}
+	if doc := path.Base(ref[:i]); doc != "." && doc != "/" {
+		if name := typeName(strings.TrimSuffix(doc, path.Ext(doc))); name != "Type" {
+			return name
+		}
+	}
+	return fallback
+}
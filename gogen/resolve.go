@@ -0,0 +1,37 @@
+package gogen
+
+import (
+	"fmt"
+
+	"github.com/eachain/jsonschema/jsi"
+)
+
+// refKeywords are keywords referring to other schemas.
+var refKeywords = []string{"$ref", "$recursiveRef", "$dynamicRef"}
+
+// refOf returns the reference of schema js, or "" if it has none.
+func refOf(js jsi.JSON) string {
+	for _, kw := range refKeywords {
+		if ref := stringOf(js, kw); ref != "" {
+			return ref
+		}
+	}
+	return ""
+}
+
+// resolve returns the schema which the reference of schema js refers to,
+// as compiling resolved it.
+func (g *generator) resolve(js jsi.JSON) (jsi.JSON, error) {
+	obj := js.(jsi.Object)
+	for _, kw := range refKeywords {
+		ref := obj.Index(kw)
+		if ref == nil || ref.Type() != jsi.TypeString {
+			continue
+		}
+		if target := g.schema.Resolve(ref); target != nil {
+			return target, nil
+		}
+		return nil, fmt.Errorf("can't resolve reference %v", ref.(jsi.String).Value())
+	}
+	return nil, fmt.Errorf("no reference in schema")
+}
@@ -0,0 +1,368 @@
+package gogen
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/eachain/jsonschema/jsi"
+)
+
+// goType is the Go type of a schema.
+type goType struct {
+	expr     string
+	nullable bool // null is allowed too
+	indirect bool // a struct declared in progress, fields of it must be pointers
+}
+
+const anyType = "interface{}"
+
+// typeOf returns the Go type of schema js, name is the name to declare
+// a type with if js needs one, eg. an object with properties or an enum.
+// If inline is false, the type for js itself is being declared.
+func (g *generator) typeOf(js jsi.JSON, name string, inline bool) (goType, error) {
+	obj, ok := js.(jsi.Object)
+	if !ok {
+		return goType{expr: anyType}, nil
+	}
+
+	if ref := refOf(js); ref != "" {
+		target, err := g.resolve(js)
+		if err != nil {
+			return goType{}, err
+		}
+		nt, err := g.declare(target, refName(ref, name))
+		if err != nil {
+			return goType{}, err
+		}
+		return goType{expr: nt.name, nullable: nullable(target), indirect: nt.isStruct && !nt.done}, nil
+	}
+
+	if inline && (isEnum(js) || g.isStruct(js)) {
+		nt, err := g.declare(js, name)
+		if err != nil {
+			return goType{}, err
+		}
+		return goType{expr: nt.name, nullable: nullable(js)}, nil
+	}
+
+	types, null := typesOf(obj)
+	if len(types) == 0 {
+		for _, kw := range []string{"anyOf", "oneOf"} {
+			if t, ok, err := g.nullableOf(obj, kw, name); ok || err != nil {
+				return t, err
+			}
+		}
+		switch {
+		case obj.Index("items") != nil || obj.Index("prefixItems") != nil:
+			types = []string{"array"}
+		case obj.Index("additionalProperties") != nil:
+			types = []string{"object"}
+		}
+	}
+	if len(types) != 1 {
+		return goType{expr: anyType}, nil
+	}
+
+	t := goType{expr: anyType, nullable: null}
+	switch types[0] {
+	case "string":
+		t.expr = "string"
+		if stringOf(js, "format") == "date-time" {
+			g.imports["time"] = true
+			t.expr = "time.Time"
+		}
+	case "integer":
+		t.expr = "int64"
+	case "number":
+		t.expr = "float64"
+	case "boolean":
+		t.expr = "bool"
+	case "array":
+		t.expr = "[]" + anyType
+		if items := obj.Index("items"); items != nil && items.Type() != jsi.TypeArray {
+			it, err := g.typeOf(items, name+"Item", true)
+			if err != nil {
+				return goType{}, err
+			}
+			t.expr = "[]" + it.expr
+			if it.nullable && !nilable(it.expr) {
+				t.expr = "[]*" + it.expr
+			}
+		}
+	case "object":
+		t.expr = "map[string]" + anyType
+		if ap := obj.Index("additionalProperties"); ap != nil && ap.Type() == jsi.TypeObject {
+			vt, err := g.typeOf(ap, name+"Value", true)
+			if err != nil {
+				return goType{}, err
+			}
+			t.expr = "map[string]" + vt.expr
+			if vt.nullable && !nilable(vt.expr) {
+				t.expr = "map[string]*" + vt.expr
+			}
+		}
+	}
+	return t, nil
+}
+
+// nullableOf returns the type of the only branch of anyOf or oneOf
+// which doesn't allow null only, if the other branches allow null only.
+func (g *generator) nullableOf(obj jsi.Object, keyword, name string) (goType, bool, error) {
+	arr, ok := obj.Index(keyword).(jsi.Array)
+	if !ok {
+		return goType{}, false, nil
+	}
+	var branch jsi.JSON
+	for i := 0; i < arr.Len(); i++ {
+		sub := arr.Index(i)
+		if sub.Type() == jsi.TypeObject {
+			if types, null := typesOf(sub.(jsi.Object)); len(types) == 0 && null {
+				continue
+			}
+		}
+		if branch != nil {
+			return goType{}, false, nil
+		}
+		branch = sub
+	}
+	if branch == nil || arr.Len() == 1 {
+		return goType{}, false, nil
+	}
+	t, err := g.typeOf(branch, name, true)
+	t.nullable = true
+	return t, true, err
+}
+
+// typesOf returns the types of keyword type, but null.
+func typesOf(obj jsi.Object) (types []string, null bool) {
+	switch t := obj.Index("type").(type) {
+	case jsi.String:
+		types = append(types, t.Value())
+	case jsi.Array:
+		for i := 0; i < t.Len(); i++ {
+			if s, ok := t.Index(i).(jsi.String); ok {
+				types = append(types, s.Value())
+			}
+		}
+	}
+	for i := 0; i < len(types); i++ {
+		if types[i] == "null" {
+			types = append(types[:i], types[i+1:]...)
+			null = true
+			i--
+		}
+	}
+	if null && len(types) == 0 {
+		types = nil
+	}
+	return
+}
+
+func nullable(js jsi.JSON) bool {
+	obj, ok := js.(jsi.Object)
+	if !ok {
+		return false
+	}
+	_, null := typesOf(obj)
+	return null
+}
+
+// nilable reports whether the zero value of Go type expr is nil.
+func nilable(expr string) bool {
+	return expr == anyType || strings.HasPrefix(expr, "*") ||
+		strings.HasPrefix(expr, "[]") || strings.HasPrefix(expr, "map[")
+}
+
+// isStruct reports whether js is an object schema with properties,
+// directly or by allOf.
+func (g *generator) isStruct(js jsi.JSON) bool {
+	obj, ok := js.(jsi.Object)
+	if !ok {
+		return false
+	}
+	if types, _ := typesOf(obj); len(types) > 1 || len(types) == 1 && types[0] != "object" {
+		return false
+	}
+	if _, ok := obj.Index("properties").(jsi.Object); ok {
+		return true
+	}
+	allOf, ok := obj.Index("allOf").(jsi.Array)
+	if !ok {
+		return false
+	}
+	for i := 0; i < allOf.Len(); i++ {
+		sub := allOf.Index(i)
+		if refOf(sub) != "" {
+			target, err := g.resolve(sub)
+			if err == nil && g.isStruct(target) {
+				return true
+			}
+		} else if g.isStruct(sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// structOf returns the struct type of object schema obj with properties,
+// structs referred to by allOf are embedded, properties of other schemas
+// in allOf are merged.
+func (g *generator) structOf(obj jsi.Object, name string) (string, error) {
+	var b bytes.Buffer
+	b.WriteString("struct {\n")
+	fields := make(map[string]bool)
+
+	if allOf, ok := obj.Index("allOf").(jsi.Array); ok {
+		for i := 0; i < allOf.Len(); i++ {
+			sub := allOf.Index(i)
+			ref := refOf(sub)
+			if ref == "" {
+				if g.isStruct(sub) {
+					if err := g.writeFields(&b, sub.(jsi.Object), name, fields); err != nil {
+						return "", err
+					}
+				}
+				continue
+			}
+			target, err := g.resolve(sub)
+			if err != nil {
+				return "", err
+			}
+			if !g.isStruct(target) {
+				continue
+			}
+			nt, err := g.declare(target, refName(ref, name))
+			if err != nil {
+				return "", err
+			}
+			if nt.name != name && !fields[nt.name] {
+				fields[nt.name] = true
+				fmt.Fprintf(&b, "\t%v\n", nt.name)
+			}
+		}
+	}
+
+	if err := g.writeFields(&b, obj, name, fields); err != nil {
+		return "", err
+	}
+	b.WriteString("}")
+	return b.String(), nil
+}
+
+// writeFields writes fields of properties of obj, skipping fields named
+// already. Optional properties are pointers unless nilable, tagged with
+// omitempty.
+func (g *generator) writeFields(b *bytes.Buffer, obj jsi.Object, name string, fields map[string]bool) error {
+	props, ok := obj.Index("properties").(jsi.Object)
+	if !ok {
+		return nil
+	}
+	required := make(map[string]bool)
+	if arr, ok := obj.Index("required").(jsi.Array); ok {
+		for i := 0; i < arr.Len(); i++ {
+			if s, ok := arr.Index(i).(jsi.String); ok {
+				required[s.Value()] = true
+			}
+		}
+	}
+
+	iter := props.Iter()
+	for iter.Next() {
+		key, sub := iter.Entry()
+		if strings.ContainsAny(key, "\"`,") {
+			continue // can't be in a json tag
+		}
+		field := fieldName(key)
+		for i := 2; fields[field]; i++ {
+			field = fieldName(key) + strconv.Itoa(i)
+		}
+		fields[field] = true
+
+		t, err := g.typeOf(sub, name+field, true)
+		if err != nil {
+			return err
+		}
+		optional := !required[key]
+		expr := t.expr
+		if (optional || t.nullable || t.indirect) && !nilable(expr) {
+			expr = "*" + expr
+		}
+		tag := key
+		if optional {
+			tag += ",omitempty"
+		}
+
+		if stringOf(sub, "title") != "" || stringOf(sub, "description") != "" {
+			writeDoc(b, "\t", "", sub)
+		}
+		fmt.Fprintf(b, "\t%v %v `json:%q`\n", field, expr, tag)
+	}
+	return nil
+}
+
+func isEnum(js jsi.JSON) bool {
+	return enumType(js) != ""
+}
+
+// enumType returns the Go type of values of enum of js, or "" if js has
+// no enum, or values of it are not of the same type of string or number.
+func enumType(js jsi.JSON) string {
+	obj, ok := js.(jsi.Object)
+	if !ok {
+		return ""
+	}
+	arr, ok := obj.Index("enum").(jsi.Array)
+	if !ok || arr.Len() == 0 {
+		return ""
+	}
+	var typ string
+	for i := 0; i < arr.Len(); i++ {
+		var t string
+		switch v := arr.Index(i).(type) {
+		case jsi.String:
+			t = "string"
+		case jsi.Number:
+			t = "float64"
+			if _, err := v.Value().Int64(); err == nil {
+				t = "int64"
+			}
+		default:
+			return ""
+		}
+		switch {
+		case typ == "" || typ == t:
+			typ = t
+		case typ == "int64" && t == "float64" || typ == "float64" && t == "int64":
+			typ = "float64"
+		default:
+			return ""
+		}
+	}
+	return typ
+}
+
+// enum returns constant specs of the enum of js, for the named type.
+func (g *generator) enum(js jsi.JSON, name string) []string {
+	if !isEnum(js) {
+		return nil
+	}
+	arr := js.(jsi.Object).Index("enum").(jsi.Array)
+	var consts []string
+	for i := 0; i < arr.Len(); i++ {
+		var ident, value string
+		switch v := arr.Index(i).(type) {
+		case jsi.String:
+			ident, value = camelCase(v.Value()), strconv.Quote(v.Value())
+		case jsi.Number:
+			value = v.Value().String()
+			ident = strings.NewReplacer("-", "Minus", ".", "_", "+", "").Replace(value)
+		}
+		if ident == "" {
+			ident = "Empty"
+		}
+		consts = append(consts, fmt.Sprintf("%v %v = %v", g.uniqueName(name+ident), name, value))
+	}
+	return consts
+}
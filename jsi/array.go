@@ -3,9 +3,10 @@ package jsi
 import "encoding/json"
 
 type jsArray struct {
-	p JSON
-	l []JSON
-	r json.RawMessage
+	p   JSON
+	l   []JSON
+	r   json.RawMessage
+	pos Position
 }
 
 func (*jsArray) Type() Type {
@@ -16,6 +17,10 @@ func (a *jsArray) Parent() JSON {
 	return a.p
 }
 
+func (a *jsArray) Position() Position {
+	return a.pos
+}
+
 func (a *jsArray) String() string {
 	a.MarshalJSON()
 	return bytes2str(a.r)
@@ -33,6 +38,9 @@ func (a *jsArray) MarshalJSON() ([]byte, error) {
 	if len(a.r) > 0 {
 		return a.r, nil
 	}
+	if a.l == nil {
+		return []byte("[]"), nil
+	}
 	b, err := json.Marshal(a.l)
 	if err != nil {
 		return nil, err
@@ -40,3 +48,12 @@ func (a *jsArray) MarshalJSON() ([]byte, error) {
 	a.r = b
 	return b, nil
 }
+
+// NewArray returns an array of copies of items.
+func NewArray(items ...JSON) JSON {
+	arr := &jsArray{l: make([]JSON, len(items))}
+	for i, item := range items {
+		arr.l[i] = cloneOf(item, arr)
+	}
+	return arr
+}
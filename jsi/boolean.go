@@ -1,8 +1,9 @@
 package jsi
 
 type jsBoolean struct {
-	p JSON
-	v bool
+	p   JSON
+	v   bool
+	pos Position
 }
 
 func (*jsBoolean) Type() Type {
@@ -13,6 +14,10 @@ func (b *jsBoolean) Parent() JSON {
 	return b.p
 }
 
+func (b *jsBoolean) Position() Position {
+	return b.pos
+}
+
 func (b *jsBoolean) Value() bool {
 	return b.v
 }
@@ -30,3 +35,7 @@ func (b *jsBoolean) MarshalJSON() ([]byte, error) {
 	}
 	return []byte("false"), nil
 }
+
+func NewBoolean(v bool) JSON {
+	return &jsBoolean{v: v}
+}
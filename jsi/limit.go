@@ -0,0 +1,226 @@
+package jsi
+
+import (
+	"fmt"
+	"io"
+)
+
+// ParseOption sets limits of JSON parsers, a limit of 0 means unlimited.
+// Input exceeding a limit fails parsing with a *LimitError.
+// WithNoTrailingData is not a limit, but a check of the input end.
+type ParseOption func(*parseOptions)
+
+type parseOptions struct {
+	maxDepth   int
+	maxBytes   int64
+	maxMembers int
+	maxString  int
+	maxNumber  int
+	noTrailing bool
+}
+
+// WithMaxDepth limits nesting depth of objects and arrays,
+// the top-level object or array is at depth 1.
+func WithMaxDepth(n int) ParseOption {
+	return func(o *parseOptions) {
+		o.maxDepth = n
+	}
+}
+
+// WithMaxBytes limits total bytes read from the input.
+func WithMaxBytes(n int64) ParseOption {
+	return func(o *parseOptions) {
+		o.maxBytes = n
+	}
+}
+
+// WithMaxMembers limits members of an object and elements of an array.
+// Elements of the top-level array of NewArrayParser are not limited.
+func WithMaxMembers(n int) ParseOption {
+	return func(o *parseOptions) {
+		o.maxMembers = n
+	}
+}
+
+// WithMaxStringLength limits bytes of a string or an object key.
+func WithMaxStringLength(n int) ParseOption {
+	return func(o *parseOptions) {
+		o.maxString = n
+	}
+}
+
+// WithMaxNumberLength limits bytes of a number literal.
+func WithMaxNumberLength(n int) ParseOption {
+	return func(o *parseOptions) {
+		o.maxNumber = n
+	}
+}
+
+// WithNoTrailingData rejects anything but spaces after the top-level value
+// with a *SyntaxError. By default parsing stops after the value, and the
+// rest of the input is left unread.
+func WithNoTrailingData() ParseOption {
+	return func(o *parseOptions) {
+		o.noTrailing = true
+	}
+}
+
+func newParseOptions(opts []ParseOption) *parseOptions {
+	o := new(parseOptions)
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// Limit is the kind of limit exceeded.
+type Limit string
+
+const (
+	LimitDepth   Limit = "nesting depth"
+	LimitBytes   Limit = "input size"
+	LimitMembers Limit = "member count"
+	LimitString  Limit = "string length"
+	LimitNumber  Limit = "number length"
+)
+
+// LimitError is returned by parsers when input exceeds a limit set by ParseOption.
+type LimitError struct {
+	Limit  Limit
+	Max    int64 // the limit
+	Offset int64 // error occurred after reading Offset bytes
+
+	Position Position // position of Offset, or of the value exceeding the limit
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%v exceeds limit %d", e.Limit, e.Max)
+}
+
+// limitReader fails reading more than max bytes with a *LimitError.
+type limitReader struct {
+	r   io.Reader
+	n   int64 // bytes left
+	max int64
+}
+
+func newLimitReader(r io.Reader, max int64) io.Reader {
+	if max <= 0 {
+		return r
+	}
+	return &limitReader{r: r, n: max, max: max}
+}
+
+func (l *limitReader) Read(p []byte) (int, error) {
+	if l.n < 0 {
+		return 0, l.err()
+	}
+	// read one more byte to tell if there is more than max
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	if l.n < 0 {
+		return n - 1, l.err()
+	}
+	return n, err
+}
+
+func (l *limitReader) err() error {
+	return &LimitError{Limit: LimitBytes, Max: l.max, Offset: l.max}
+}
+
+// scanReader fails reading a string or a number longer than its limit
+// with a *LimitError, so the decoder never buffers the whole of it.
+// An escape in a string is counted as one byte, which is no more than it
+// decodes to, the exact length is checked after decoding.
+type scanReader struct {
+	r         io.Reader
+	maxString int
+	maxNumber int
+
+	off   int64 // bytes scanned
+	state scanState
+	start int64 // offset of the string or number scanning
+	n     int   // length of it so far
+	hex   int   // hex digits of \u escape left
+	err   error
+}
+
+type scanState int
+
+const (
+	scanValue scanState = iota
+	scanString
+	scanEscape
+	scanHex
+	scanNumber
+)
+
+func newScanReader(r io.Reader, opts *parseOptions) io.Reader {
+	if opts.maxString <= 0 && opts.maxNumber <= 0 {
+		return r
+	}
+	return &scanReader{r: r, maxString: opts.maxString, maxNumber: opts.maxNumber}
+}
+
+func (s *scanReader) Read(p []byte) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	n, err := s.r.Read(p)
+	for i, c := range p[:n] {
+		if s.err = s.scan(c); s.err != nil {
+			// bytes before the exceeding one are still decoded
+			return i, s.err
+		}
+		s.off++
+	}
+	return n, err
+}
+
+func (s *scanReader) scan(c byte) error {
+	switch s.state {
+	case scanValue:
+		if c == '"' {
+			s.state, s.start, s.n = scanString, s.off, 0
+		} else if c == '-' || ('0' <= c && c <= '9') {
+			s.state, s.start, s.n = scanNumber, s.off, 0
+			return s.scan(c)
+		}
+	case scanString:
+		switch c {
+		case '"':
+			s.state = scanValue
+			return nil
+		case '\\':
+			s.state = scanEscape
+		}
+		if s.n++; s.maxString > 0 && s.n > s.maxString {
+			return &LimitError{Limit: LimitString, Max: int64(s.maxString), Offset: s.start}
+		}
+	case scanEscape:
+		s.state = scanString
+		if c == 'u' {
+			s.state, s.hex = scanHex, 4
+		}
+	case scanHex:
+		if s.hex--; s.hex == 0 {
+			s.state = scanString
+		}
+	case scanNumber:
+		if !isNumberByte(c) {
+			s.state = scanValue
+			return s.scan(c)
+		}
+		if s.n++; s.maxNumber > 0 && s.n > s.maxNumber {
+			return &LimitError{Limit: LimitNumber, Max: int64(s.maxNumber), Offset: s.start}
+		}
+	}
+	return nil
+}
+
+func isNumberByte(c byte) bool {
+	return ('0' <= c && c <= '9') || c == '.' || c == 'e' || c == 'E' || c == '+' || c == '-'
+}
@@ -0,0 +1,179 @@
+package jsi
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLimits(t *testing.T) {
+	tests := []struct {
+		json  string
+		opt   ParseOption
+		limit Limit
+	}{
+		{`[[[1]]]`, WithMaxDepth(2), LimitDepth},
+		{`{"a": {"b": 1}}`, WithMaxDepth(1), LimitDepth},
+		{`[1, 2, 3]`, WithMaxMembers(2), LimitMembers},
+		{`{"a": 1, "b": 2, "c": 3}`, WithMaxMembers(2), LimitMembers},
+		{`["abcd"]`, WithMaxStringLength(3), LimitString},
+		{`{"abcd": 1}`, WithMaxStringLength(3), LimitString},
+		{`["\u0061\u0062\n\t"]`, WithMaxStringLength(3), LimitString},
+		{`[12345]`, WithMaxNumberLength(4), LimitNumber},
+		{`[-1.5e+10]`, WithMaxNumberLength(4), LimitNumber},
+		{`[1, 2, 3, 4, 5]`, WithMaxBytes(8), LimitBytes},
+	}
+	for _, tt := range tests {
+		_, err := NewBytesParser([]byte(tt.json), tt.opt).Parse()
+		var le *LimitError
+		if !errors.As(err, &le) {
+			t.Errorf("%v: got %v, want *LimitError", tt.json, err)
+			continue
+		}
+		if le.Limit != tt.limit {
+			t.Errorf("%v: got %v, want %v", tt.json, le.Limit, tt.limit)
+		}
+		if !le.Position.IsValid() {
+			t.Errorf("%v: no position of %v", tt.json, le)
+		}
+	}
+}
+
+func TestLimitsNotExceeded(t *testing.T) {
+	tests := []struct {
+		json string
+		opt  ParseOption
+	}{
+		{`[[1]]`, WithMaxDepth(2)},
+		{`[1, 2]`, WithMaxMembers(2)},
+		{`["abc", {"abc": "a\"c"}]`, WithMaxStringLength(3)},
+		{`["\u00e9"]`, WithMaxStringLength(2)},
+		{`[1234, -1e5, "123456", true, null]`, WithMaxNumberLength(4)},
+		{`[1, 2]`, WithMaxBytes(6)},
+	}
+	for _, tt := range tests {
+		if _, err := NewBytesParser([]byte(tt.json), tt.opt).Parse(); err != nil {
+			t.Errorf("%v: %v", tt.json, err)
+		}
+	}
+}
+
+func TestNoTrailingData(t *testing.T) {
+	for _, s := range []string{`{"a": 1} x`, `[1] [2]`, `1 2`} {
+		if _, err := NewBytesParser([]byte(s)).Parse(); err != nil {
+			t.Errorf("%v: %v", s, err)
+		}
+		_, err := NewBytesParser([]byte(s), WithNoTrailingData()).Parse()
+		var se *SyntaxError
+		if !errors.As(err, &se) {
+			t.Errorf("%v: got %v, want *SyntaxError", s, err)
+		}
+	}
+	if _, err := NewBytesParser([]byte("[1] \n\t"), WithNoTrailingData()).Parse(); err != nil {
+		t.Errorf("trailing spaces: %v", err)
+	}
+}
+
+// endless reads prefix followed by endless fill, it counts bytes read.
+type endless struct {
+	prefix string
+	fill   byte
+	n      int
+}
+
+func (e *endless) Read(p []byte) (int, error) {
+	for i := range p {
+		if e.n < len(e.prefix) {
+			p[i] = e.prefix[e.n]
+		} else {
+			p[i] = e.fill
+		}
+		e.n++
+	}
+	return len(p), nil
+}
+
+func (e *endless) Close() error { return nil }
+
+func TestLimitsWhileReading(t *testing.T) {
+	// a string or a number is never read as a whole
+	for _, r := range []*endless{
+		{prefix: `["`, fill: 'x'},
+		{prefix: `{"`, fill: 'x'},
+		{prefix: `[`, fill: '1'},
+	} {
+		_, err := NewReaderParser(r, WithMaxStringLength(10), WithMaxNumberLength(10)).Parse()
+		var le *LimitError
+		if !errors.As(err, &le) {
+			t.Fatalf("%v: got %v, want *LimitError", r.prefix, err)
+		}
+		if le.Offset != 1 || le.Position.Column != 2 {
+			t.Errorf("%v: got offset %d at %v, want 1", r.prefix, le.Offset, le.Position)
+		}
+		if r.n > 1<<16 {
+			t.Errorf("%v: read %d bytes", r.prefix, r.n)
+		}
+	}
+}
+
+func TestReadCloserParserDrainLimited(t *testing.T) {
+	// the rest drained after a syntax error is limited too
+	r := &endless{prefix: `[1] `, fill: 'x'}
+	_, err := NewReadCloserParser(r, WithMaxBytes(1024), WithNoTrailingData()).Parse()
+	if err == nil {
+		t.Fatal("trailing data parsed")
+	}
+	if r.n > 1<<16 {
+		t.Errorf("read %d bytes", r.n)
+	}
+
+	r = &endless{prefix: `["`, fill: 'x'}
+	_, err = NewReadCloserParser(r, WithMaxBytes(1024)).Parse()
+	var le *LimitError
+	if !errors.As(err, &le) || le.Limit != LimitBytes {
+		t.Fatalf("got %v, want input size exceeded", err)
+	}
+	if r.n > 1<<16 {
+		t.Errorf("read %d bytes", r.n)
+	}
+}
+
+func TestYAMLLimits(t *testing.T) {
+	tests := []struct {
+		yaml      string
+		opt       ParseOption
+		limit     Limit
+		line, col int
+	}{
+		{"a:\n  b:\n    c: 1\n", WithMaxDepth(2), LimitDepth, 3, 5},
+		{"a: [[1]]\n", WithMaxDepth(2), LimitDepth, 1, 5},
+		{"a: &x [1]\nb:\n  c: *x\n", WithMaxDepth(2), LimitDepth, 3, 6},
+		{"- 1\n- 2\n- 3\n", WithMaxMembers(2), LimitMembers, 3, 1},
+		{"{a: 1, b: 2, c: 3}", WithMaxMembers(2), LimitMembers, 1, 14},
+		{"[1, 2, 3]", WithMaxMembers(2), LimitMembers, 1, 8},
+		{"a: abcd\n", WithMaxStringLength(3), LimitString, 1, 4},
+		{"abcd: 1\n", WithMaxStringLength(3), LimitString, 1, 1},
+		{"a: |\n  abcd\n", WithMaxStringLength(3), LimitString, 1, 4},
+		{"a: 12345\n", WithMaxNumberLength(4), LimitNumber, 1, 4},
+		{"a: 1\nb: 2\n", WithMaxBytes(6), LimitBytes, 2, 2},
+	}
+	for _, tt := range tests {
+		_, err := NewYAMLBytesParser([]byte(tt.yaml), tt.opt).Parse()
+		var le *LimitError
+		if !errors.As(err, &le) {
+			t.Errorf("%q: got %v, want *LimitError", tt.yaml, err)
+			continue
+		}
+		if le.Limit != tt.limit {
+			t.Errorf("%q: got %v, want %v", tt.yaml, le.Limit, tt.limit)
+		}
+		if le.Position.Line != tt.line || le.Position.Column != tt.col {
+			t.Errorf("%q: got %v, want %d:%d", tt.yaml, le.Position, tt.line, tt.col)
+		}
+	}
+
+	js, err := NewYAMLBytesParser([]byte("a: [1, 2]\nb: abc\n"),
+		WithMaxDepth(2), WithMaxMembers(2), WithMaxStringLength(3)).Parse()
+	if err != nil || js.Type() != TypeObject {
+		t.Errorf("got %v, %v", js, err)
+	}
+}
@@ -0,0 +1,126 @@
+package jsi
+
+// MutableObject is an Object whose members can be changed in place,
+// which is true of objects parsed by this package and copied by Clone.
+type MutableObject interface {
+	Object
+	Set(key string, val JSON) // adds or replaces member key by a copy of val
+	Delete(key string)
+}
+
+// MutableArray is an Array whose items can be changed in place,
+// which is true of arrays parsed by this package and copied by Clone.
+type MutableArray interface {
+	Array
+	Set(i int, val JSON) // replaces item i by a copy of val
+	Append(val JSON)     // appends a copy of val
+}
+
+// Clone returns a deep copy of js without parent, so the copy can be
+// changed leaving js as it is. Positions of values are kept.
+func Clone(js JSON) JSON {
+	return cloneOf(js, nil)
+}
+
+func cloneOf(js, parent JSON) JSON {
+	pos := PositionOf(js)
+	switch js.Type() {
+	case TypeObject:
+		src := js.(Object)
+		obj := &jsObject{p: parent, m: make(map[string]JSON, src.Len()), pos: pos}
+		iter := src.Iter()
+		for iter.Next() {
+			key, val := iter.Entry()
+			obj.k = append(obj.k, key)
+			obj.m[key] = cloneOf(val, obj)
+		}
+		return obj
+	case TypeArray:
+		src := js.(Array)
+		arr := &jsArray{p: parent, l: make([]JSON, src.Len()), pos: pos}
+		for i := range arr.l {
+			arr.l[i] = cloneOf(src.Index(i), arr)
+		}
+		return arr
+	case TypeString:
+		return &jsString{p: parent, s: js.(String).Value(), pos: pos}
+	case TypeNumber:
+		return &jsNumber{p: parent, n: js.(Number).Value(), pos: pos}
+	case TypeBoolean:
+		return &jsBoolean{p: parent, v: js.(Boolean).Value(), pos: pos}
+	default:
+		return &jsNULL{p: parent, pos: pos}
+	}
+}
+
+func (o *jsObject) Set(key string, val JSON) {
+	if _, ok := o.m[key]; !ok {
+		// k may be shared by copies of a YAML alias
+		o.k = append(o.k[:len(o.k):len(o.k)], key)
+	}
+	o.m[key] = cloneOf(val, o)
+	changed(o)
+}
+
+func (o *jsObject) Delete(key string) {
+	if _, ok := o.m[key]; !ok {
+		return
+	}
+	delete(o.m, key)
+	k := make([]string, 0, len(o.k)-1)
+	for _, s := range o.k {
+		if s != key {
+			k = append(k, s)
+		}
+	}
+	o.k = k
+	changed(o)
+}
+
+func (a *jsArray) Set(i int, val JSON) {
+	a.l[i] = cloneOf(val, a)
+	changed(a)
+}
+
+func (a *jsArray) Append(val JSON) {
+	a.l = append(a.l, cloneOf(val, a))
+	changed(a)
+}
+
+// changed drops cached encodings of js and its ancestors.
+func changed(js JSON) {
+	for ; js != nil; js = js.Parent() {
+		switch v := js.(type) {
+		case *jsObject:
+			v.r = nil
+		case *jsArray:
+			v.r = nil
+		}
+	}
+}
+
+// Replace replaces js in its parent by a copy of val and returns the copy,
+// or a copy without parent if js has no parent. It returns nil if the
+// parent of js is not mutable.
+func Replace(js, val JSON) JSON {
+	switch p := js.Parent().(type) {
+	case nil:
+		return Clone(val)
+	case MutableObject:
+		iter := p.Iter()
+		for iter.Next() {
+			if key, v := iter.Entry(); v == js {
+				p.Set(key, val)
+				return p.Index(key)
+			}
+		}
+	case MutableArray:
+		for i := 0; i < p.Len(); i++ {
+			if p.Index(i) == js {
+				p.Set(i, val)
+				return p.Index(i)
+			}
+		}
+	}
+	return nil
+}
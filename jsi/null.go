@@ -1,7 +1,8 @@
 package jsi
 
 type jsNULL struct {
-	p JSON
+	p   JSON
+	pos Position
 }
 
 func (*jsNULL) Type() Type {
@@ -12,6 +13,10 @@ func (n *jsNULL) Parent() JSON {
 	return n.p
 }
 
+func (n *jsNULL) Position() Position {
+	return n.pos
+}
+
 func (*jsNULL) Value() {}
 
 func (*jsNULL) String() string {
@@ -21,3 +26,7 @@ func (*jsNULL) String() string {
 func (*jsNULL) MarshalJSON() ([]byte, error) {
 	return []byte("null"), nil
 }
+
+func NewNULL() JSON {
+	return new(jsNULL)
+}
@@ -3,8 +3,9 @@ package jsi
 import "encoding/json"
 
 type jsNumber struct {
-	p JSON
-	n json.Number
+	p   JSON
+	n   json.Number
+	pos Position
 }
 
 func (*jsNumber) Type() Type {
@@ -15,6 +16,10 @@ func (n *jsNumber) Parent() JSON {
 	return n.p
 }
 
+func (n *jsNumber) Position() Position {
+	return n.pos
+}
+
 func (n *jsNumber) Value() json.Number {
 	return n.n
 }
@@ -26,3 +31,7 @@ func (n *jsNumber) String() string {
 func (n *jsNumber) MarshalJSON() ([]byte, error) {
 	return json.Marshal(n.n)
 }
+
+func NewNumber(n json.Number) JSON {
+	return &jsNumber{n: n}
+}
@@ -6,10 +6,11 @@ import (
 )
 
 type jsObject struct {
-	p JSON
-	m map[string]JSON
-	r json.RawMessage
-	k []string
+	p   JSON
+	m   map[string]JSON
+	r   json.RawMessage
+	k   []string
+	pos Position
 }
 
 type jsObjectIter struct {
@@ -25,6 +26,10 @@ func (o *jsObject) Parent() JSON {
 	return o.p
 }
 
+func (o *jsObject) Position() Position {
+	return o.pos
+}
+
 func (o *jsObject) String() string {
 	o.MarshalJSON()
 	return bytes2str(o.r)
@@ -65,6 +70,7 @@ func (o *jsObject) MarshalJSON() ([]byte, error) {
 		if err != nil {
 			return nil, err
 		}
+		n = b.Len() - 1
 		if b.Bytes()[n] == '\n' {
 			b.Truncate(n)
 		}
@@ -73,6 +79,11 @@ func (o *jsObject) MarshalJSON() ([]byte, error) {
 	return b.Bytes(), nil
 }
 
+// NewObject returns an empty MutableObject.
+func NewObject() JSON {
+	return &jsObject{m: make(map[string]JSON)}
+}
+
 func (o *jsObject) Iter() ObjectIter {
 	return &jsObjectIter{o: o, i: -1}
 }
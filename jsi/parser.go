@@ -3,6 +3,7 @@ package jsi
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -11,72 +12,150 @@ import (
 )
 
 type jsParser struct {
-	dec *json.Decoder
+	dec  *json.Decoder
+	pos  *posReader
+	opts *parseOptions
 }
 
 type SyntaxError struct {
 	msg    string // description of error
 	Offset int64  // error occurred after reading Offset bytes
+
+	Position Position // position of Offset
 }
 
 func (e *SyntaxError) Error() string { return e.msg }
 
+func (p jsParser) syntaxError(msg string) *SyntaxError {
+	off := p.dec.InputOffset()
+	return &SyntaxError{msg: msg, Offset: off, Position: p.pos.position(off)}
+}
+
+// limitError reports a value at pos exceeding limit max.
+func (p jsParser) limitError(limit Limit, max int, pos Position) *LimitError {
+	return &LimitError{Limit: limit, Max: int64(max), Offset: p.dec.InputOffset(), Position: pos}
+}
+
+// token reads the next token and the position where it starts.
+func (p jsParser) token() (json.Token, Position, error) {
+	start := p.dec.InputOffset()
+	tok, err := p.dec.Token()
+	if err != nil {
+		var se *json.SyntaxError
+		var le *LimitError
+		if errors.As(err, &se) {
+			err = &SyntaxError{msg: se.Error(), Offset: se.Offset, Position: p.pos.position(se.Offset)}
+		} else if errors.As(err, &le) && !le.Position.IsValid() {
+			le.Position = p.pos.position(le.Offset)
+		}
+		return nil, Position{}, err
+	}
+	pos := p.pos.position(p.pos.tokenStart(start))
+	p.pos.drop(p.dec.InputOffset())
+
+	switch v := tok.(type) {
+	case string:
+		if p.opts.maxString > 0 && len(v) > p.opts.maxString {
+			return nil, Position{}, p.limitError(LimitString, p.opts.maxString, pos)
+		}
+	case json.Number:
+		if p.opts.maxNumber > 0 && len(v) > p.opts.maxNumber {
+			return nil, Position{}, p.limitError(LimitNumber, p.opts.maxNumber, pos)
+		}
+	}
+	return tok, pos, nil
+}
+
+// next reads the next token inside an object or array.
+func (p jsParser) next() (json.Token, Position, error) {
+	tok, pos, err := p.token()
+	if err == io.EOF {
+		err = p.syntaxError("unexpected end of JSON input")
+	}
+	return tok, pos, err
+}
+
 func (p jsParser) Parse() (JSON, error) {
-	t, err := p.dec.Token()
+	t, pos, err := p.token()
 	if err == io.EOF {
-		return nil, &SyntaxError{"unexpected end of JSON input", p.dec.InputOffset()}
+		return nil, p.syntaxError("unexpected end of JSON input")
 	}
-	return p.parse(nil, t)
+	if err != nil {
+		return nil, err
+	}
+	js, err := p.parse(nil, t, pos, 0)
+	if err != nil || !p.opts.noTrailing {
+		return js, err
+	}
+
+	// only spaces may follow the value
+	t, _, err = p.token()
+	if err == io.EOF {
+		return js, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nil, p.syntaxError(fmt.Sprintf("invalid token after top-level value '%v'", t))
 }
 
-func (p jsParser) parse(parent JSON, t json.Token) (JSON, error) {
+// parse parses the value starting with token t,
+// which is nested in depth objects or arrays.
+func (p jsParser) parse(parent JSON, t json.Token, pos Position, depth int) (JSON, error) {
 	switch v := t.(type) {
 	case json.Delim:
+		if (v == '{' || v == '[') && p.opts.maxDepth > 0 && depth >= p.opts.maxDepth {
+			return nil, p.limitError(LimitDepth, p.opts.maxDepth, pos)
+		}
 		switch v {
 		case '{':
-			return p.parseObject(parent)
+			return p.parseObject(parent, pos, depth+1)
 		case '[':
-			return p.parseArray(parent)
+			return p.parseArray(parent, pos, depth+1)
 		default:
-			return nil, &SyntaxError{fmt.Sprintf("invalid character '%v'", v), p.dec.InputOffset()}
+			return nil, p.syntaxError(fmt.Sprintf("invalid character '%v'", v))
 		}
 	case bool:
-		return &jsBoolean{p: parent, v: v}, nil
+		return &jsBoolean{p: parent, v: v, pos: pos}, nil
 	case json.Number:
-		return &jsNumber{p: parent, n: v}, nil
+		return &jsNumber{p: parent, n: v, pos: pos}, nil
 	case string:
-		return &jsString{p: parent, s: v}, nil
+		return &jsString{p: parent, s: v, pos: pos}, nil
 	case nil:
-		return &jsNULL{p: parent}, nil
+		return &jsNULL{p: parent, pos: pos}, nil
 	default:
-		return nil, &SyntaxError{fmt.Sprintf("invalid character '%v'", v), p.dec.InputOffset()}
+		return nil, p.syntaxError(fmt.Sprintf("invalid character '%v'", v))
 	}
 }
 
-func (p jsParser) parseObject(parent JSON) (JSON, error) {
+func (p jsParser) parseObject(parent JSON, pos Position, depth int) (JSON, error) {
 	obj := &jsObject{
-		p: parent,
-		m: make(map[string]JSON),
+		p:   parent,
+		m:   make(map[string]JSON),
+		pos: pos,
 	}
 
 	for p.dec.More() {
-		tok, err := p.dec.Token()
+		if p.opts.maxMembers > 0 && len(obj.k) >= p.opts.maxMembers {
+			return nil, p.limitError(LimitMembers, p.opts.maxMembers, pos)
+		}
+		tok, _, err := p.next()
 		if err != nil {
 			return nil, err
 		}
 		key, ok := tok.(string)
 		if !ok {
-			return nil, &SyntaxError{fmt.Sprintf("invalid object key type '%T'", tok), p.dec.InputOffset()}
+			return nil, p.syntaxError(fmt.Sprintf("invalid object key type '%T'", tok))
 		}
 		if _, ok = obj.m[key]; ok {
-			return nil, &SyntaxError{fmt.Sprintf("duplicate object key '%v'", key), p.dec.InputOffset()}
+			return nil, p.syntaxError(fmt.Sprintf("duplicate object key '%v'", key))
 		}
 
-		tok, err = p.dec.Token()
+		tok, pos, err := p.next()
 		if err != nil {
 			return nil, err
 		}
-		val, err := p.parse(obj, tok)
+		val, err := p.parse(obj, tok, pos, depth)
 		if err != nil {
 			return nil, err
 		}
@@ -84,59 +163,72 @@ func (p jsParser) parseObject(parent JSON) (JSON, error) {
 		obj.m[key] = val
 	}
 
-	tok, err := p.dec.Token()
+	tok, _, err := p.next()
 	if err != nil {
 		return nil, err
 	}
 	delim, ok := tok.(json.Delim)
 	if !ok {
-		return nil, &SyntaxError{fmt.Sprintf("invalid token after object '%v'", tok), p.dec.InputOffset()}
+		return nil, p.syntaxError(fmt.Sprintf("invalid token after object '%v'", tok))
 	}
 	if delim != '}' {
-		return nil, &SyntaxError{fmt.Sprintf("invalid character '%v' after object", tok), p.dec.InputOffset()}
+		return nil, p.syntaxError(fmt.Sprintf("invalid character '%v' after object", tok))
 	}
 	return obj, nil
 }
 
-func (p jsParser) parseArray(parent JSON) (JSON, error) {
-	arr := &jsArray{p: parent}
+func (p jsParser) parseArray(parent JSON, pos Position, depth int) (JSON, error) {
+	arr := &jsArray{p: parent, pos: pos}
 
 	for p.dec.More() {
-		tok, err := p.dec.Token()
+		if p.opts.maxMembers > 0 && len(arr.l) >= p.opts.maxMembers {
+			return nil, p.limitError(LimitMembers, p.opts.maxMembers, pos)
+		}
+		tok, pos, err := p.next()
 		if err != nil {
 			return nil, err
 		}
-		val, err := p.parse(arr, tok)
+		val, err := p.parse(arr, tok, pos, depth)
 		if err != nil {
 			return nil, err
 		}
 		arr.l = append(arr.l, val)
 	}
 
-	tok, err := p.dec.Token()
+	tok, _, err := p.next()
 	if err != nil {
 		return nil, err
 	}
 	delim, ok := tok.(json.Delim)
 	if !ok {
-		return nil, &SyntaxError{fmt.Sprintf("invalid token after array '%v'", tok), p.dec.InputOffset()}
+		return nil, p.syntaxError(fmt.Sprintf("invalid token after array '%v'", tok))
 	}
 	if delim != ']' {
-		return nil, &SyntaxError{fmt.Sprintf("invalid character '%v' after array", tok), p.dec.InputOffset()}
+		return nil, p.syntaxError(fmt.Sprintf("invalid character '%v' after array", tok))
 	}
 	return arr, nil
 }
 
-func NewReaderParser(r io.Reader) Parser {
+// NewReaderParser parses JSON from r, parsed values are Positioned.
+// Limits of untrusted input can be set by opts.
+func NewReaderParser(r io.Reader, opts ...ParseOption) Parser {
+	return newReaderParser(r, "", newParseOptions(opts))
+}
+
+func newReaderParser(r io.Reader, file string, opts *parseOptions) Parser {
+	pos := newPosReader(newScanReader(newLimitReader(r, opts.maxBytes), opts))
+	pos.file = file
 	p := jsParser{
-		dec: json.NewDecoder(r),
+		dec:  json.NewDecoder(pos),
+		pos:  pos,
+		opts: opts,
 	}
 	p.dec.UseNumber()
 	return p
 }
 
-func NewBytesParser(p []byte) Parser {
-	return NewReaderParser(bytes.NewReader(p))
+func NewBytesParser(p []byte, opts ...ParseOption) Parser {
+	return NewReaderParser(bytes.NewReader(p), opts...)
 }
 
 type goTypesParser struct {
@@ -166,12 +258,12 @@ func (s errorParser) Parse() (JSON, error) {
 	return nil, s.err
 }
 
-func NewURLParser(u *url.URL) Parser {
+func NewURLParser(u *url.URL, opts ...ParseOption) Parser {
 	switch u.Scheme {
 	case "http", "https":
-		return NewHTTPParser(u.String())
+		return NewHTTPParser(u.String(), opts...)
 	case "file":
-		return NewFileParser(u.Path)
+		return NewFileParser(u.Path, opts...)
 	default:
 		return errorParser{fmt.Errorf("unsupport url scheme '%v'", u.Scheme)}
 	}
@@ -179,30 +271,44 @@ func NewURLParser(u *url.URL) Parser {
 
 type readCloserParser struct {
 	rc io.ReadCloser
+	r  io.Reader // rc limited by maxBytes
 	p  Parser
 }
 
-func NewReadCloserParser(rc io.ReadCloser) Parser {
-	return readCloserParser{rc: rc, p: NewReaderParser(rc)}
+func NewReadCloserParser(rc io.ReadCloser, opts ...ParseOption) Parser {
+	return newReadCloserParser(rc, "", newParseOptions(opts))
 }
 
-func (p readCloserParser) Parse() (JSON, error) {
+func newReadCloserParser(rc io.ReadCloser, file string, opts *parseOptions) Parser {
+	// limited here, so the rest drained is limited too
+	r := newLimitReader(rc, opts.maxBytes)
+	o := *opts
+	o.maxBytes = 0
+	return readCloserParser{rc: rc, r: r, p: newReaderParser(r, file, &o)}
+}
+
+// Parse drains the rest of input before closing it, so a connection of
+// an http response can be reused, unless the input exceeds a limit.
+func (p readCloserParser) Parse() (js JSON, err error) {
 	defer func() {
-		io.Copy(io.Discard, p.rc)
+		var le *LimitError
+		if !errors.As(err, &le) {
+			io.Copy(io.Discard, p.r)
+		}
 		p.rc.Close()
 	}()
 	return p.p.Parse()
 }
 
-func NewFileParser(path string) Parser {
+func NewFileParser(path string, opts ...ParseOption) Parser {
 	fp, err := os.Open(path)
 	if err != nil {
 		return errorParser{err}
 	}
-	return NewReadCloserParser(fp)
+	return newReadCloserParser(fp, path, newParseOptions(opts))
 }
 
-func NewHTTPParser(url string) Parser {
+func NewHTTPParser(url string, opts ...ParseOption) Parser {
 	resp, err := http.Get(url)
 	if err != nil {
 		return errorParser{err}
@@ -212,5 +318,5 @@ func NewHTTPParser(url string) Parser {
 		resp.Body.Close()
 		return errorParser{fmt.Errorf("HTTP GET %v: %v", url, resp.Status)}
 	}
-	return NewReadCloserParser(resp.Body)
+	return newReadCloserParser(resp.Body, url, newParseOptions(opts))
 }
@@ -0,0 +1,131 @@
+package jsi
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+// Position is where a JSON value starts in its source,
+// Line and Column start from 1, Column counts in characters.
+type Position struct {
+	File   string // file name or url of the source, may be empty
+	Offset int64  // byte offset
+	Line   int
+	Column int
+}
+
+// IsValid reports whether the position is known.
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+// String returns "file:line:col", or "line:col" without file.
+func (p Position) String() string {
+	if !p.IsValid() {
+		if p.File != "" {
+			return p.File
+		}
+		return "-"
+	}
+	if p.File == "" {
+		return fmt.Sprintf("%d:%d", p.Line, p.Column)
+	}
+	return fmt.Sprintf("%v:%d:%d", p.File, p.Line, p.Column)
+}
+
+// Positioned is implemented by JSON values produced by reader parsers.
+type Positioned interface {
+	Position() Position
+}
+
+// PositionOf returns the source position of js,
+// or an invalid Position if unknown.
+func PositionOf(js JSON) Position {
+	if p, ok := js.(Positioned); ok {
+		return p.Position()
+	}
+	return Position{}
+}
+
+// posReader keeps bytes read by the decoder from base on, which are
+// dropped once they are consumed, to locate offsets of tokens.
+type posReader struct {
+	r     io.Reader
+	file  string
+	shift int64 // offset of the reader in the source
+
+	base int64  // offset of buf[head]
+	buf  []byte // bytes from base on, after head consumed ones
+	head int
+	line int // line of base
+	col  int // characters between base and its line start
+}
+
+// compactSize is the least bytes consumed to move the rest of buf
+// to its start, so every byte is moved a few times at most.
+const compactSize = 4096
+
+func newPosReader(r io.Reader) *posReader {
+	return &posReader{r: r, line: 1}
+}
+
+func (pr *posReader) Read(p []byte) (int, error) {
+	n, err := pr.r.Read(p)
+	pr.buf = append(pr.buf, p[:n]...)
+	return n, err
+}
+
+// tokenStart skips spaces and separators from offset off,
+// and returns the offset of the next token.
+func (pr *posReader) tokenStart(off int64) int64 {
+	buf := pr.buf[pr.head:]
+	for i := off - pr.base; i >= 0 && i < int64(len(buf)); i++ {
+		switch buf[i] {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			continue
+		}
+		return pr.base + i
+	}
+	return off
+}
+
+// position returns the position of offset off, which is not before base.
+func (pr *posReader) position(off int64) Position {
+	buf := pr.buf[pr.head:]
+	i := off - pr.base
+	if i < 0 || i > int64(len(buf)) {
+		return Position{File: pr.file, Offset: pr.shift + off}
+	}
+
+	line, col := pr.advance(buf[:i])
+	return Position{File: pr.file, Offset: pr.shift + off, Line: line, Column: col + 1}
+}
+
+// drop discards bytes before offset off, which have been consumed.
+func (pr *posReader) drop(off int64) {
+	buf := pr.buf[pr.head:]
+	i := off - pr.base
+	if i <= 0 || i > int64(len(buf)) {
+		return
+	}
+
+	pr.line, pr.col = pr.advance(buf[:i])
+	pr.head += int(i)
+	pr.base = off
+	if pr.head >= compactSize && pr.head >= len(pr.buf)/2 {
+		pr.buf = pr.buf[:copy(pr.buf, pr.buf[pr.head:])]
+		pr.head = 0
+	}
+}
+
+// advance returns the line and column after bytes p from base.
+func (pr *posReader) advance(p []byte) (line, col int) {
+	n := bytes.Count(p, []byte{'\n'})
+	if n == 0 {
+		return pr.line, pr.col + utf8.RuneCount(p)
+	}
+	j := bytes.LastIndexByte(p, '\n') + 1
+	return pr.line + n, utf8.RuneCount(p[j:])
+}
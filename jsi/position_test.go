@@ -0,0 +1,100 @@
+package jsi
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestPositions(t *testing.T) {
+	src := "{\n  \"a\": [1, \"x\",\n    {\"b\": null}],\n  \"é\": true\n}"
+	js, err := NewReaderParser(strings.NewReader(src)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	obj := js.(Object)
+	a := obj.Index("a")
+	arr := a.(Array)
+	tests := []struct {
+		js        JSON
+		line, col int
+	}{
+		{js, 1, 1},
+		{a, 2, 8},
+		{arr.Index(0), 2, 9},
+		{arr.Index(1), 2, 12},
+		{arr.Index(2), 3, 5},
+		{arr.Index(2).(Object).Index("b"), 3, 11},
+		{obj.Index("é"), 4, 8}, // columns count characters
+	}
+	for _, tt := range tests {
+		pos := PositionOf(tt.js)
+		if pos.Line != tt.line || pos.Column != tt.col {
+			t.Errorf("%v: got %v, want %d:%d", tt.js, pos, tt.line, tt.col)
+		}
+	}
+	if pos := PositionOf(NewString("x")); pos.IsValid() {
+		t.Errorf("position of a value not parsed: %v", pos)
+	}
+}
+
+func TestPositionsLargeInput(t *testing.T) {
+	// positions are kept across many reads and compactions of the buffer
+	var b bytes.Buffer
+	b.WriteString("[\n")
+	n := 20000
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteString(",\n")
+		}
+		fmt.Fprintf(&b, "  {\"i\": %d, \"s\": \"%v\"}", i, strings.Repeat("é", i%7))
+	}
+	b.WriteString("\n]")
+
+	js, err := NewReaderParser(bytes.NewReader(b.Bytes())).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	arr := js.(Array)
+	for _, i := range []int{0, 1, 4095, n / 2, n - 1} {
+		elem := arr.Index(i).(Object)
+		if pos := PositionOf(arr.Index(i)); pos.Line != i+2 || pos.Column != 3 {
+			t.Errorf("element %d: got %v, want %d:3", i, pos, i+2)
+		}
+		if pos := PositionOf(elem.Index("s")); pos.Line != i+2 || pos.Column != 16+len(fmt.Sprint(i)) {
+			t.Errorf("element %d: got %v of s", i, pos)
+		}
+	}
+}
+
+func TestSyntaxErrorPosition(t *testing.T) {
+	_, err := NewReaderParser(strings.NewReader("{\n  \"a\": [1,,]\n}")).Parse()
+	se, ok := err.(*SyntaxError)
+	if !ok {
+		t.Fatalf("got %v, want *SyntaxError", err)
+	}
+	if se.Position.Line != 2 {
+		t.Errorf("got %v, want line 2", se.Position)
+	}
+}
+
+func BenchmarkReaderParser(b *testing.B) {
+	var buf bytes.Buffer
+	buf.WriteString("[")
+	for i := 0; i < 100000; i++ {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		fmt.Fprintf(&buf, `{"id": %d, "name": "item %d", "tags": ["a", "b"], "ok": true}`, i, i)
+	}
+	buf.WriteString("]")
+	src := buf.Bytes()
+	b.SetBytes(int64(len(src)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := NewReaderParser(bytes.NewReader(src)).Parse(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package jsi
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// arrayParser parses elements of a top-level array one by one.
+type arrayParser struct {
+	jsParser
+	started bool
+	err     error // sticky, io.EOF after the closing ']'
+}
+
+// NewArrayParser parses elements of a top-level JSON array from r,
+// each Parse returns the next element, and io.EOF after the last one
+// if only spaces follow the array.
+// Only one element is kept in memory, elements have no parent and
+// are Positioned in the whole input. Limits set by opts apply to each
+// element, except WithMaxBytes limits the whole input.
+func NewArrayParser(r io.Reader, opts ...ParseOption) Parser {
+	return &arrayParser{jsParser: newReaderParser(r, "", newParseOptions(opts)).(jsParser)}
+}
+
+func (p *arrayParser) Parse() (JSON, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	js, err := p.element()
+	if err != nil {
+		p.err = err
+	}
+	return js, err
+}
+
+func (p *arrayParser) element() (JSON, error) {
+	if !p.started {
+		p.started = true
+		tok, _, err := p.token()
+		if err == io.EOF {
+			return nil, p.syntaxError("unexpected end of JSON input")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+			return nil, p.syntaxError(fmt.Sprintf("top-level value is not an array: '%v'", tok))
+		}
+	}
+
+	if !p.dec.More() {
+		tok, _, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != ']' {
+			return nil, p.syntaxError(fmt.Sprintf("invalid token after array '%v'", tok))
+		}
+
+		// only spaces may follow the array
+		tok, _, err = p.token()
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		if err != nil {
+			return nil, err
+		}
+		return nil, p.syntaxError(fmt.Sprintf("invalid token after top-level value '%v'", tok))
+	}
+
+	tok, pos, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	return p.parse(nil, tok, pos, 1)
+}
+
+// ndjsonParser parses newline-delimited JSON line by line.
+type ndjsonParser struct {
+	r    *bufio.Reader
+	opts *parseOptions // for each line, without maxBytes
+	off  int64         // offset of the next line
+	line int           // number of lines read
+	eof  bool
+}
+
+// NewNDJSONParser parses newline-delimited JSON from r, each Parse returns
+// the value of the next non-blank line, and io.EOF after the last one.
+// Only one line is kept in memory. A line failed to parse returns a
+// *SyntaxError or *LimitError, and the next Parse goes on with the
+// following line.
+// Limits set by opts apply to each line, except WithMaxBytes limits
+// the whole input.
+func NewNDJSONParser(r io.Reader, opts ...ParseOption) Parser {
+	o := newParseOptions(opts)
+	r = newLimitReader(r, o.maxBytes)
+	o.maxBytes = 0
+	return &ndjsonParser{r: bufio.NewReader(r), opts: o}
+}
+
+func (p *ndjsonParser) Parse() (JSON, error) {
+	for !p.eof {
+		b, err := p.r.ReadBytes('\n')
+		if err == io.EOF {
+			p.eof = true
+		} else if err != nil {
+			return nil, err
+		}
+
+		off := p.off
+		p.off += int64(len(b))
+		p.line++
+		if len(bytes.TrimSpace(b)) == 0 {
+			continue
+		}
+		return p.parseLine(b, off, p.line)
+	}
+	return nil, io.EOF
+}
+
+func (p *ndjsonParser) parseLine(b []byte, off int64, line int) (JSON, error) {
+	lp := newReaderParser(bytes.NewReader(b), "", p.opts).(jsParser)
+	lp.pos.shift = off
+	lp.pos.line = line
+
+	js, err := lp.Parse()
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err = lp.token(); err != io.EOF {
+		return nil, lp.syntaxError("invalid character after top-level value")
+	}
+	return js, nil
+}
@@ -0,0 +1,142 @@
+package jsi
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// text returns js in JSON.
+func text(js JSON) string {
+	b, _ := json.Marshal(js)
+	return string(b)
+}
+
+// parseAll parses values by p until an error.
+func parseAll(p Parser) ([]string, error) {
+	var values []string
+	for {
+		js, err := p.Parse()
+		if err != nil {
+			return values, err
+		}
+		values = append(values, text(js))
+	}
+}
+
+func TestArrayParser(t *testing.T) {
+	p := NewArrayParser(strings.NewReader("[1,\n {\"a\": [true]}, \"x\"]  "))
+	var positions []string
+	for {
+		js, err := p.Parse()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if js.Parent() != nil {
+			t.Errorf("%v has a parent", js)
+		}
+		positions = append(positions, PositionOf(js).String())
+	}
+	if got := strings.Join(positions, " "); got != "1:2 2:2 2:17" {
+		t.Errorf("positions: got %v", got)
+	}
+	if _, err := p.Parse(); err != io.EOF {
+		t.Errorf("parse after EOF: %v", err)
+	}
+
+	tests := []struct {
+		src    string
+		values int
+	}{
+		{``, 0},
+		{`{"a": 1}`, 0},
+		{`[1, 2`, 2},
+		{`[1, 2] 3`, 2},
+		{`[1, }]`, 1},
+	}
+	for _, tt := range tests {
+		values, err := parseAll(NewArrayParser(strings.NewReader(tt.src)))
+		var se *SyntaxError
+		if !errors.As(err, &se) {
+			t.Errorf("%q: got error %v, want syntax error", tt.src, err)
+		}
+		if len(values) != tt.values {
+			t.Errorf("%q: got values %v", tt.src, values)
+		}
+	}
+
+	if values, err := parseAll(NewArrayParser(strings.NewReader(`[]`))); err != io.EOF || len(values) > 0 {
+		t.Errorf("empty array: got %v, %v", values, err)
+	}
+}
+
+func TestArrayParserLimits(t *testing.T) {
+	// depth limits each element
+	p := NewArrayParser(strings.NewReader(`[[1], [[2]]]`), WithMaxDepth(2))
+	values, err := parseAll(p)
+	var le *LimitError
+	if len(values) != 1 || !errors.As(err, &le) {
+		t.Errorf("got %v, %v", values, err)
+	}
+
+	// bytes limit the whole input
+	p = NewArrayParser(strings.NewReader(`[1, 2, 3, 4]`), WithMaxBytes(6))
+	if values, err = parseAll(p); !errors.As(err, &le) || len(values) > 2 {
+		t.Errorf("got %v, %v", values, err)
+	}
+}
+
+func TestNDJSONParser(t *testing.T) {
+	src := "{\"a\": 1}\n\n  [1, 2]\r\n\"x\" 1\n{\n\"b\": 2}\ntrue"
+	p := NewNDJSONParser(strings.NewReader(src))
+	tests := []struct {
+		value string
+		pos   string
+		err   bool
+	}{
+		{`{"a":1}`, "1:1", false},
+		{`[1,2]`, "3:3", false},
+		{"", "", true}, // trailing value
+		{"", "", true}, // a value across lines
+		{"", "", true},
+		{`true`, "7:1", false},
+	}
+	for i, tt := range tests {
+		js, err := p.Parse()
+		if tt.err {
+			var se *SyntaxError
+			if !errors.As(err, &se) {
+				t.Errorf("%d: got %v, %v, want syntax error", i, js, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%d: %v", i, err)
+		}
+		if text(js) != tt.value || PositionOf(js).String() != tt.pos {
+			t.Errorf("%d: got %v at %v, want %v at %v", i, js, PositionOf(js), tt.value, tt.pos)
+		}
+	}
+	if js, err := p.Parse(); err != io.EOF {
+		t.Errorf("parse after the last line: %v, %v", js, err)
+	}
+}
+
+func TestNDJSONParserLimits(t *testing.T) {
+	p := NewNDJSONParser(strings.NewReader("\"abc\"\n\"abcdef\"\n\"a\"\n"), WithMaxStringLength(4))
+	var le *LimitError
+	if js, err := p.Parse(); err != nil || text(js) != `"abc"` {
+		t.Errorf("line 1: %v, %v", js, err)
+	}
+	if _, err := p.Parse(); !errors.As(err, &le) {
+		t.Errorf("line 2: got %v, want limit error", err)
+	}
+	if js, err := p.Parse(); err != nil || text(js) != `"a"` {
+		t.Errorf("line 3: %v, %v", js, err)
+	}
+}
@@ -6,9 +6,10 @@ import (
 )
 
 type jsString struct {
-	p JSON
-	s string
-	r json.RawMessage
+	p   JSON
+	s   string
+	r   json.RawMessage
+	pos Position
 }
 
 func (*jsString) Type() Type {
@@ -19,6 +20,10 @@ func (s *jsString) Parent() JSON {
 	return s.p
 }
 
+func (s *jsString) Position() Position {
+	return s.pos
+}
+
 func (s *jsString) Value() string {
 	return s.s
 }
@@ -47,3 +52,7 @@ func (s *jsString) MarshalJSON() ([]byte, error) {
 	}
 	return s.r, nil
 }
+
+func NewString(s string) JSON {
+	return &jsString{s: s}
+}
@@ -0,0 +1,1250 @@
+package jsi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// yamlParser parses YAML 1.2 documents into JSON values. Each call of
+// Parse returns the next document of the stream, and io.EOF after the
+// last one.
+//
+// Mapping keys must be strings: plain keys resolved to other types, eg.
+// 200 or true in "200: ok", are rejected and should be quoted, as are
+// collection keys, empty keys and duplicate keys. Anchors, aliases and
+// merge keys ("<<") are supported, tags other than the core ones (!!str,
+// !!int, ...) are ignored.
+//
+// ParseOptions limit it as they do JSON parsers, except that input is
+// read as a whole before parsing, and lengths of scalars are checked
+// after they are parsed. Collections copied by aliases count for depth.
+type yamlParser struct {
+	r    io.Reader
+	c    io.Closer // closed after reading if not nil
+	file string
+	opts *parseOptions
+
+	src    []byte
+	off    int
+	lines  []int // offsets of line starts
+	loaded bool
+	err    error
+
+	anchors map[string]JSON
+	aliased int // nodes copied by aliases
+	depth   int // nesting depth of collections
+}
+
+// yamlMaxAliased limits nodes copied by aliases in a document,
+// against alias bombs like "billion laughs".
+const yamlMaxAliased = 1 << 18
+
+type yamlError struct {
+	err error // *SyntaxError or *LimitError
+}
+
+// NewYAMLParser parses YAML documents from r, parsed values are Positioned.
+func NewYAMLParser(r io.Reader, opts ...ParseOption) Parser {
+	return &yamlParser{r: r, opts: newParseOptions(opts)}
+}
+
+func NewYAMLBytesParser(p []byte, opts ...ParseOption) Parser {
+	return &yamlParser{r: bytes.NewReader(p), opts: newParseOptions(opts)}
+}
+
+func NewYAMLFileParser(path string, opts ...ParseOption) Parser {
+	fp, err := os.Open(path)
+	if err != nil {
+		return errorParser{err}
+	}
+	return &yamlParser{r: fp, c: fp, file: path, opts: newParseOptions(opts)}
+}
+
+// IsYAMLFile reports whether a file name or an URL path is of YAML by
+// its extension, ".yaml" or ".yml" in any case.
+func IsYAMLFile(name string) bool {
+	name = strings.ToLower(name)
+	return strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml")
+}
+
+func (p *yamlParser) load() error {
+	if p.loaded {
+		return p.err
+	}
+	p.loaded = true
+
+	p.src, p.err = io.ReadAll(newLimitReader(p.r, p.opts.maxBytes))
+	if p.c != nil {
+		p.c.Close()
+	}
+	if p.err == nil {
+		p.src = bytes.TrimPrefix(p.src, []byte("\xef\xbb\xbf"))
+	}
+	p.lines = append(p.lines, 0)
+	for i, c := range p.src {
+		if c == '\n' {
+			p.lines = append(p.lines, i+1)
+		}
+	}
+	if le, ok := p.err.(*LimitError); ok {
+		le.Position = p.position(len(p.src))
+	}
+	return p.err
+}
+
+func (p *yamlParser) Parse() (js JSON, err error) {
+	if err = p.load(); err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			ye, ok := r.(yamlError)
+			if !ok {
+				panic(r)
+			}
+			js, err = nil, ye.err
+			p.err = ye.err
+		}
+	}()
+	return p.document()
+}
+
+func (p *yamlParser) fail(off int, format string, args ...any) {
+	panic(yamlError{&SyntaxError{
+		msg:      fmt.Sprintf(format, args...),
+		Offset:   int64(off),
+		Position: p.position(off),
+	}})
+}
+
+// limit fails parsing by a value at off exceeding limit max.
+func (p *yamlParser) limit(limit Limit, max int, off int) {
+	panic(yamlError{&LimitError{
+		Limit:    limit,
+		Max:      int64(max),
+		Offset:   int64(off),
+		Position: p.position(off),
+	}})
+}
+
+// enter enters a collection at off, leave must be called after it.
+func (p *yamlParser) enter(off int) {
+	if p.depth++; p.opts.maxDepth > 0 && p.depth > p.opts.maxDepth {
+		p.limit(LimitDepth, p.opts.maxDepth, off)
+	}
+}
+
+func (p *yamlParser) leave() {
+	p.depth--
+}
+
+// checkMembers fails if a collection with n members can't have one more.
+func (p *yamlParser) checkMembers(n, off int) {
+	if p.opts.maxMembers > 0 && n >= p.opts.maxMembers {
+		p.limit(LimitMembers, p.opts.maxMembers, off)
+	}
+}
+
+func (p *yamlParser) checkString(s string, off int) {
+	if p.opts.maxString > 0 && len(s) > p.opts.maxString {
+		p.limit(LimitString, p.opts.maxString, off)
+	}
+}
+
+func (p *yamlParser) position(off int) Position {
+	i := sort.Search(len(p.lines), func(i int) bool { return p.lines[i] > off }) - 1
+	return Position{
+		File:   p.file,
+		Offset: int64(off),
+		Line:   i + 1,
+		Column: utf8.RuneCount(p.src[p.lines[i]:off]) + 1,
+	}
+}
+
+func (p *yamlParser) lineStart(off int) int {
+	i := sort.Search(len(p.lines), func(i int) bool { return p.lines[i] > off }) - 1
+	return p.lines[i]
+}
+
+// col returns the indentation of offset off in its line.
+func (p *yamlParser) col(off int) int {
+	return off - p.lineStart(off)
+}
+
+func (p *yamlParser) eof() bool {
+	return p.off >= len(p.src)
+}
+
+// at returns the byte at p.off+k, or 0 if out of range.
+func (p *yamlParser) at(k int) byte {
+	if i := p.off + k; i >= 0 && i < len(p.src) {
+		return p.src[i]
+	}
+	return 0
+}
+
+func isYAMLSpace(c byte) bool {
+	return c == ' ' || c == '\t'
+}
+
+func isYAMLBreak(c byte) bool {
+	return c == '\n' || c == '\r'
+}
+
+// isYAMLBlank reports whether c is a space, a line break or the end (0).
+func isYAMLBlank(c byte) bool {
+	return c == 0 || isYAMLSpace(c) || isYAMLBreak(c)
+}
+
+func isYAMLFlowIndicator(c byte) bool {
+	return c == ',' || c == '[' || c == ']' || c == '{' || c == '}'
+}
+
+func (p *yamlParser) skipSpaces() {
+	for isYAMLSpace(p.at(0)) {
+		p.off++
+	}
+}
+
+func (p *yamlParser) skipBreak() {
+	if p.at(0) == '\r' && p.at(1) == '\n' {
+		p.off += 2
+	} else if isYAMLBreak(p.at(0)) {
+		p.off++
+	}
+}
+
+func (p *yamlParser) skipLine() {
+	for !p.eof() && !isYAMLBreak(p.at(0)) {
+		p.off++
+	}
+}
+
+// skipBlank skips spaces, comments and line breaks.
+func (p *yamlParser) skipBlank() {
+	for {
+		p.skipSpaces()
+		if p.at(0) == '#' {
+			p.skipLine()
+		}
+		if !isYAMLBreak(p.at(0)) {
+			return
+		}
+		p.skipBreak()
+	}
+}
+
+// atLineEnd reports whether only spaces and comments are left in the line.
+func (p *yamlParser) atLineEnd() bool {
+	c := p.at(0)
+	return c == 0 || c == '#' || isYAMLBreak(c)
+}
+
+func (p *yamlParser) atMarker(marker string) bool {
+	return p.col(p.off) == 0 && bytes.HasPrefix(p.src[p.off:], []byte(marker)) &&
+		isYAMLBlank(p.at(3))
+}
+
+func (p *yamlParser) atDocMarker() bool {
+	return !p.eof() && (p.atMarker("---") || p.atMarker("..."))
+}
+
+func (p *yamlParser) atSeqEntry() bool {
+	return p.at(0) == '-' && isYAMLBlank(p.at(1))
+}
+
+// checkIndent rejects tabs in the indentation of a block entry.
+func (p *yamlParser) checkIndent() {
+	ls := p.lineStart(p.off)
+	for i := ls; i < p.off; i++ {
+		switch p.src[i] {
+		case ' ':
+		case '\t':
+			p.fail(i, "tabs are not allowed in indentation")
+		default:
+			return
+		}
+	}
+}
+
+func (p *yamlParser) document() (JSON, error) {
+	p.anchors = nil
+	p.aliased = 0
+
+	for {
+		p.skipBlank()
+		switch {
+		case !p.eof() && p.col(p.off) == 0 && p.at(0) == '%': // directives
+			p.skipLine()
+			continue
+		case p.atMarker("..."):
+			p.off += 3
+			continue
+		}
+		break
+	}
+
+	explicit := false
+	if p.atMarker("---") {
+		explicit = true
+		p.off += 3
+		p.skipBlank()
+	}
+	if p.eof() && !explicit {
+		return nil, io.EOF
+	}
+
+	var js JSON
+	if p.eof() || p.atDocMarker() {
+		js = &jsNULL{pos: p.position(p.off)}
+	} else {
+		js = p.blockNode(-1, false)
+	}
+
+	p.skipBlank()
+	if p.atMarker("...") {
+		p.off += 3
+		p.skipBlank()
+	}
+	if !p.eof() && !p.atMarker("---") && p.at(0) != '%' {
+		p.fail(p.off, "unexpected content after document")
+	}
+	return js, nil
+}
+
+// props parses the anchor and tag of a node.
+func (p *yamlParser) props() (anchor, tag string, ok bool) {
+	for {
+		switch p.at(0) {
+		case '&':
+			off := p.off
+			p.off++
+			if anchor = p.name(); anchor == "" {
+				p.fail(off, "anchor without name")
+			}
+		case '!':
+			start := p.off
+			for !isYAMLBlank(p.at(0)) && !(p.at(0) == ',' || p.at(0) == ']' || p.at(0) == '}') {
+				p.off++
+			}
+			tag = string(p.src[start:p.off])
+		default:
+			return
+		}
+		ok = true
+		p.skipSpaces()
+	}
+}
+
+// name reads the name of an anchor or alias.
+func (p *yamlParser) name() string {
+	start := p.off
+	for c := p.at(0); !isYAMLBlank(c) && !isYAMLFlowIndicator(c); c = p.at(0) {
+		p.off++
+	}
+	return string(p.src[start:p.off])
+}
+
+func (p *yamlParser) anchor(name string, js JSON) {
+	if name == "" {
+		return
+	}
+	if p.anchors == nil {
+		p.anchors = make(map[string]JSON)
+	}
+	p.anchors[name] = js
+}
+
+// blockNode parses a node in block context. Continuation lines of it are
+// indented more than parent, inline means it follows a mapping key in the
+// same line, where block collections are not allowed.
+func (p *yamlParser) blockNode(parent int, inline bool) JSON {
+	anchor, tag, ok := p.props()
+	if ok && p.atLineEnd() {
+		off := p.off
+		p.skipBlank()
+		if p.eof() || p.atDocMarker() || p.col(p.off) <= parent {
+			p.off = off
+			js := p.scalarNode("", true, tag, off)
+			p.anchor(anchor, js)
+			return js
+		}
+		inline = false
+	}
+
+	js := p.blockContent(parent, inline, tag)
+	p.anchor(anchor, js)
+	return js
+}
+
+func (p *yamlParser) blockContent(parent int, inline bool, tag string) JSON {
+	start := p.off
+	if p.eof() || p.atDocMarker() {
+		return p.scalarNode("", true, tag, start)
+	}
+
+	switch c := p.at(0); {
+	case p.atSeqEntry():
+		if inline {
+			p.fail(start, "block sequence entries are not allowed in this context")
+		}
+		return p.blockSequence(p.col(start))
+	case c == '?' && isYAMLBlank(p.at(1)):
+		p.fail(start, "complex mapping keys are not supported")
+	case c == '|' || c == '>':
+		return p.blockScalar(parent, tag)
+	}
+
+	if p.isImplicitKey() {
+		if inline {
+			p.fail(start, "mapping values are not allowed in this context")
+		}
+		return p.blockMapping(p.col(start))
+	}
+
+	var js JSON
+	switch p.at(0) {
+	case '[', '{':
+		js = p.flowCollection()
+	case '*':
+		js = p.alias()
+	case '"', '\'':
+		js = p.scalarNode(p.quoted(), false, tag, start)
+	default:
+		return p.scalarNode(p.plain(parent, false), true, tag, start)
+	}
+
+	p.skipSpaces()
+	if p.at(0) == ':' && isYAMLBlank(p.at(1)) {
+		p.fail(start, "mapping keys must be strings")
+	}
+	if !p.atLineEnd() {
+		p.fail(p.off, "unexpected content after node")
+	}
+	return js
+}
+
+// isImplicitKey reports whether the line from p.off is "key: ...".
+func (p *yamlParser) isImplicitKey() bool {
+	i := p.off
+	switch p.src[i] {
+	case '"', '\'':
+		if i = p.quotedEnd(i); i < 0 {
+			return false
+		}
+		for i < len(p.src) && isYAMLSpace(p.src[i]) {
+			i++
+		}
+		return i < len(p.src) && p.src[i] == ':' && (i+1 == len(p.src) || isYAMLBlank(p.src[i+1]))
+	case '[', '{', '#':
+		return false
+	}
+
+	for ; i < len(p.src) && !isYAMLBreak(p.src[i]); i++ {
+		switch c := p.src[i]; {
+		case c == '#' && i > p.off && isYAMLSpace(p.src[i-1]):
+			return false
+		case c == ':' && (i+1 == len(p.src) || isYAMLBlank(p.src[i+1])):
+			return true
+		}
+	}
+	return false
+}
+
+// quotedEnd returns the offset after the quoted scalar at offset i,
+// or -1 if it doesn't end in the same line.
+func (p *yamlParser) quotedEnd(i int) int {
+	q := p.src[i]
+	for i++; i < len(p.src) && !isYAMLBreak(p.src[i]); i++ {
+		switch c := p.src[i]; {
+		case q == '"' && c == '\\':
+			i++
+		case c == q:
+			if q == '\'' && i+1 < len(p.src) && p.src[i+1] == '\'' {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return -1
+}
+
+func (p *yamlParser) blockMapping(indent int) JSON {
+	p.enter(p.off)
+	defer p.leave()
+	obj := &jsObject{m: make(map[string]JSON), pos: p.position(p.off)}
+	var merges []JSON
+	for {
+		p.checkIndent()
+		keyOff := p.off
+		key, merge := p.blockKey()
+		p.off++ // ':'
+		p.skipSpaces()
+
+		var val JSON
+		if p.atLineEnd() {
+			off := p.off
+			p.skipBlank()
+			switch c := p.col(p.off); {
+			case p.eof() || p.atDocMarker():
+				val = &jsNULL{pos: p.position(off)}
+			case c > indent:
+				val = p.blockNode(indent, false)
+			case c == indent && p.atSeqEntry():
+				val = p.blockSequence(c)
+			default:
+				val = &jsNULL{pos: p.position(off)}
+			}
+		} else {
+			val = p.blockNode(indent, true)
+		}
+
+		if merge {
+			merges = append(merges, val)
+		} else {
+			p.setEntry(obj, key, val, keyOff)
+		}
+
+		p.skipBlank()
+		if p.eof() || p.atDocMarker() {
+			break
+		}
+		c := p.col(p.off)
+		if c < indent {
+			break
+		}
+		if c > indent {
+			p.fail(p.off, "bad indentation of a mapping entry")
+		}
+		if !p.isImplicitKey() {
+			if p.atSeqEntry() {
+				p.fail(p.off, "block sequence entries are not allowed in this context")
+			}
+			p.fail(p.off, "expected a mapping key")
+		}
+	}
+	p.merge(obj, merges)
+	return obj
+}
+
+// blockKey parses the key of a block mapping entry and stops at ':',
+// merge reports whether it is the merge key "<<".
+func (p *yamlParser) blockKey() (key string, merge bool) {
+	start := p.off
+	switch p.at(0) {
+	case '"', '\'':
+		key = p.quoted()
+		p.skipSpaces()
+		return key, false
+	case '*':
+		key = p.keyOf(p.alias(), start)
+		p.skipSpaces()
+		return key, false
+	}
+
+	i := p.off
+	for !(p.src[i] == ':' && (i+1 == len(p.src) || isYAMLBlank(p.src[i+1]))) {
+		i++
+	}
+	key = strings.TrimRight(string(p.src[p.off:i]), " \t")
+	p.off = i
+	if key == "" {
+		p.fail(start, "mapping keys must not be empty")
+	}
+	key = p.keyOf(p.resolveScalar(key, true, "", start), start)
+	return key, key == "<<"
+}
+
+// keyOf converts a node into a mapping key, which must be a string.
+func (p *yamlParser) keyOf(js JSON, off int) string {
+	if v, ok := js.(*jsString); ok {
+		return v.s
+	}
+	p.fail(off, "mapping keys must be strings, got %v; quote it", js.Type())
+	return ""
+}
+
+func (p *yamlParser) setEntry(obj *jsObject, key string, val JSON, off int) {
+	if _, ok := obj.m[key]; ok {
+		p.fail(off, "duplicate mapping key '%v'", key)
+	}
+	p.checkMembers(len(obj.k), off)
+	p.checkString(key, off)
+	setParent(val, obj)
+	obj.k = append(obj.k, key)
+	obj.m[key] = val
+}
+
+// merge adds entries of merged mappings which are not in obj.
+func (p *yamlParser) merge(obj *jsObject, merges []JSON) {
+	add := func(js JSON) {
+		src, ok := js.(*jsObject)
+		if !ok {
+			p.fail(int(PositionOf(js).Offset), "merge value should be a mapping or a sequence of mappings")
+		}
+		for _, key := range src.k {
+			if _, ok := obj.m[key]; !ok {
+				p.setEntry(obj, key, src.m[key], int(obj.pos.Offset))
+			}
+		}
+	}
+
+	for _, js := range merges {
+		if arr, ok := js.(*jsArray); ok {
+			for _, v := range arr.l {
+				add(v)
+			}
+		} else {
+			add(js)
+		}
+	}
+}
+
+func (p *yamlParser) blockSequence(indent int) JSON {
+	p.enter(p.off)
+	defer p.leave()
+	arr := &jsArray{pos: p.position(p.off)}
+	for {
+		p.checkIndent()
+		p.checkMembers(len(arr.l), p.off)
+		p.off++ // '-'
+		p.skipSpaces()
+
+		var val JSON
+		if p.atLineEnd() {
+			off := p.off
+			p.skipBlank()
+			if !p.eof() && !p.atDocMarker() && p.col(p.off) > indent {
+				val = p.blockNode(indent, false)
+			} else {
+				val = &jsNULL{pos: p.position(off)}
+			}
+		} else {
+			val = p.blockNode(indent, false)
+		}
+		setParent(val, arr)
+		arr.l = append(arr.l, val)
+
+		p.skipBlank()
+		if p.eof() || p.atDocMarker() {
+			break
+		}
+		c := p.col(p.off)
+		if c < indent || (c == indent && !p.atSeqEntry()) {
+			break
+		}
+		if c > indent {
+			p.fail(p.off, "bad indentation of a sequence entry")
+		}
+	}
+	return arr
+}
+
+// plain reads a plain scalar, which may continue in lines indented
+// more than parent, or any lines in flow context.
+func (p *yamlParser) plain(parent int, flow bool) string {
+	var b []byte
+	for {
+		end := p.off
+		for i := p.off; i < len(p.src) && !isYAMLBreak(p.src[i]); i++ {
+			c := p.src[i]
+			if c == '#' && i > p.off && isYAMLSpace(p.src[i-1]) {
+				break
+			}
+			if c == ':' && (i+1 == len(p.src) || isYAMLBlank(p.src[i+1]) ||
+				flow && isYAMLFlowIndicator(p.src[i+1])) {
+				if !flow {
+					p.fail(i, "mapping values are not allowed in this context")
+				}
+				break
+			}
+			if flow && isYAMLFlowIndicator(c) {
+				break
+			}
+			if !isYAMLSpace(c) {
+				end = i + 1
+			}
+		}
+		b = append(b, p.src[p.off:end]...)
+		p.off = end
+
+		// look for continuation lines
+		save := p.off
+		p.skipSpaces()
+		if !isYAMLBreak(p.at(0)) {
+			p.off = save
+			return string(b)
+		}
+		breaks := 0
+		for isYAMLBreak(p.at(0)) {
+			p.skipBreak()
+			breaks++
+			p.skipSpaces()
+		}
+		if p.eof() || p.at(0) == '#' || p.atDocMarker() ||
+			!flow && p.col(p.off) <= parent ||
+			flow && (isYAMLFlowIndicator(p.at(0)) || p.at(0) == ':') {
+			p.off = save
+			return string(b)
+		}
+
+		if breaks == 1 {
+			b = append(b, ' ')
+		} else {
+			b = append(b, bytes.Repeat([]byte{'\n'}, breaks-1)...)
+		}
+	}
+}
+
+// quoted reads a single or double quoted scalar.
+func (p *yamlParser) quoted() string {
+	start := p.off
+	q := p.at(0)
+	p.off++
+
+	var b []byte
+	for {
+		if p.eof() {
+			p.fail(start, "unterminated quoted scalar")
+		}
+		switch c := p.at(0); {
+		case q == '\'' && c == '\'':
+			if p.at(1) == '\'' {
+				b = append(b, '\'')
+				p.off += 2
+				continue
+			}
+			p.off++
+			return string(b)
+
+		case q == '"' && c == '"':
+			p.off++
+			return string(b)
+
+		case q == '"' && c == '\\':
+			if isYAMLBreak(p.at(1)) { // escaped line break
+				p.off++
+				p.skipBreak()
+				p.skipSpaces()
+				continue
+			}
+			b = p.escape(b)
+
+		case isYAMLBreak(c):
+			b = bytes.TrimRight(b, " \t")
+			breaks := 0
+			for isYAMLBreak(p.at(0)) {
+				p.skipBreak()
+				breaks++
+				p.skipSpaces()
+			}
+			if p.atDocMarker() {
+				p.fail(start, "unterminated quoted scalar")
+			}
+			if breaks == 1 {
+				b = append(b, ' ')
+			} else {
+				b = append(b, bytes.Repeat([]byte{'\n'}, breaks-1)...)
+			}
+
+		default:
+			b = append(b, c)
+			p.off++
+		}
+	}
+}
+
+var yamlEscapes = map[byte]rune{
+	'0': 0, 'a': '\a', 'b': '\b', 't': '\t', '\t': '\t', 'n': '\n',
+	'v': '\v', 'f': '\f', 'r': '\r', 'e': 0x1b, ' ': ' ', '"': '"',
+	'/': '/', '\\': '\\', 'N': 0x85, '_': 0xa0, 'L': 0x2028, 'P': 0x2029,
+}
+
+func (p *yamlParser) escape(b []byte) []byte {
+	start := p.off
+	c := p.at(1)
+	p.off += 2
+	if r, ok := yamlEscapes[c]; ok {
+		return utf8.AppendRune(b, r)
+	}
+
+	n := map[byte]int{'x': 2, 'u': 4, 'U': 8}[c]
+	if n == 0 || p.off+n > len(p.src) {
+		p.fail(start, "invalid escape in double quoted scalar")
+	}
+	var r rune
+	for _, h := range p.src[p.off : p.off+n] {
+		switch {
+		case h >= '0' && h <= '9':
+			r = r<<4 | rune(h-'0')
+		case h >= 'a' && h <= 'f':
+			r = r<<4 | rune(h-'a'+10)
+		case h >= 'A' && h <= 'F':
+			r = r<<4 | rune(h-'A'+10)
+		default:
+			p.fail(start, "invalid escape in double quoted scalar")
+		}
+	}
+	p.off += n
+	return utf8.AppendRune(b, r)
+}
+
+// blockScalar reads a literal (|) or folded (>) block scalar.
+func (p *yamlParser) blockScalar(parent int, tag string) JSON {
+	start := p.off
+	literal := p.at(0) == '|'
+	p.off++
+
+	var chomp byte
+	indent := 0
+	for i := 0; i < 2; i++ {
+		switch c := p.at(0); {
+		case (c == '+' || c == '-') && chomp == 0:
+			chomp = c
+			p.off++
+		case c >= '1' && c <= '9' && indent == 0:
+			indent = parent + int(c-'0')
+			if indent < 0 {
+				indent = 0
+			}
+			p.off++
+		}
+	}
+	p.skipSpaces()
+	if p.at(0) == '#' && isYAMLSpace(p.at(-1)) {
+		p.skipLine()
+	}
+	if !p.atLineEnd() {
+		p.fail(p.off, "invalid block scalar header")
+	}
+
+	n := -1 // indentation of content, detected by the first non-empty line
+	if indent > 0 {
+		n = indent
+	}
+	var lines []string
+	for !p.eof() {
+		brk := p.off
+		p.skipBreak()
+		if p.eof() {
+			break // the break ends the last line, not an empty line
+		}
+		ls := p.off
+		j := ls
+		for j < len(p.src) && p.src[j] == ' ' {
+			j++
+		}
+		le := j
+		for le < len(p.src) && p.src[le] != '\n' {
+			le++
+		}
+		line := strings.TrimSuffix(string(p.src[ls:le]), "\r")
+
+		if strings.TrimLeft(line, " ") == "" {
+			if n >= 0 && len(line) > n {
+				lines = append(lines, line[n:])
+			} else {
+				lines = append(lines, "")
+			}
+			p.off = le
+			continue
+		}
+		if n < 0 {
+			if j-ls <= parent {
+				p.off = brk
+				break
+			}
+			n = j - ls
+		}
+		if j-ls < n || p.atDocMarker() {
+			p.off = brk
+			break
+		}
+		lines = append(lines, line[n:])
+		p.off = le
+	}
+
+	trailing := 0
+	for trailing < len(lines) && lines[len(lines)-1-trailing] == "" {
+		trailing++
+	}
+	body := lines[:len(lines)-trailing]
+
+	var text string
+	if literal {
+		text = strings.Join(body, "\n")
+	} else {
+		text = foldLines(body)
+	}
+	switch {
+	case len(body) == 0:
+		if chomp == '+' {
+			text = strings.Repeat("\n", trailing)
+		}
+	case chomp == '+':
+		text += strings.Repeat("\n", trailing+1)
+	case chomp != '-':
+		text += "\n"
+	}
+	return p.scalarNode(text, false, tag, start)
+}
+
+// foldLines joins lines of a folded block scalar.
+func foldLines(lines []string) string {
+	var b strings.Builder
+	breaks := 0
+	first := true
+	prevMore := false
+	for _, line := range lines {
+		if line == "" {
+			breaks++
+			continue
+		}
+		more := line[0] == ' ' || line[0] == '\t'
+		switch {
+		case first:
+			b.WriteString(strings.Repeat("\n", breaks))
+		case more || prevMore:
+			b.WriteString(strings.Repeat("\n", breaks+1))
+		case breaks == 0:
+			b.WriteByte(' ')
+		default:
+			b.WriteString(strings.Repeat("\n", breaks))
+		}
+		b.WriteString(line)
+		first, prevMore, breaks = false, more, 0
+	}
+	return b.String()
+}
+
+func (p *yamlParser) alias() JSON {
+	start := p.off
+	p.off++ // '*'
+	name := p.name()
+	js, ok := p.anchors[name]
+	if !ok {
+		p.fail(start, "unknown anchor '%v'", name)
+	}
+	return p.clone(js, start)
+}
+
+// clone copies an anchored node for an alias at off, the copy has no parent.
+func (p *yamlParser) clone(js JSON, off int) JSON {
+	if p.aliased++; p.aliased > yamlMaxAliased {
+		p.fail(off, "too many nodes copied by aliases")
+	}
+	switch v := js.(type) {
+	case *jsObject:
+		p.enter(off)
+		defer p.leave()
+		obj := &jsObject{m: make(map[string]JSON, len(v.m)), k: v.k, pos: v.pos}
+		for key, val := range v.m {
+			val = p.clone(val, off)
+			setParent(val, obj)
+			obj.m[key] = val
+		}
+		return obj
+	case *jsArray:
+		p.enter(off)
+		defer p.leave()
+		arr := &jsArray{l: make([]JSON, len(v.l)), pos: v.pos}
+		for i, val := range v.l {
+			val = p.clone(val, off)
+			setParent(val, arr)
+			arr.l[i] = val
+		}
+		return arr
+	case *jsString:
+		return &jsString{s: v.s, pos: v.pos}
+	case *jsNumber:
+		return &jsNumber{n: v.n, pos: v.pos}
+	case *jsBoolean:
+		return &jsBoolean{v: v.v, pos: v.pos}
+	default:
+		return &jsNULL{pos: PositionOf(js)}
+	}
+}
+
+func setParent(js, parent JSON) {
+	switch v := js.(type) {
+	case *jsObject:
+		v.p = parent
+	case *jsArray:
+		v.p = parent
+	case *jsString:
+		v.p = parent
+	case *jsNumber:
+		v.p = parent
+	case *jsBoolean:
+		v.p = parent
+	case *jsNULL:
+		v.p = parent
+	}
+}
+
+func (p *yamlParser) flowCollection() JSON {
+	start := p.off
+	pos := p.position(start)
+	open := p.at(0)
+	p.off++
+	p.enter(start)
+	defer p.leave()
+
+	if open == '[' {
+		arr := &jsArray{pos: pos}
+		for {
+			p.skipBlank()
+			if p.at(0) == ']' {
+				p.off++
+				return arr
+			}
+			if p.eof() {
+				p.fail(start, "unterminated flow sequence")
+			}
+			p.checkMembers(len(arr.l), p.off)
+			val := p.flowNode()
+			p.skipBlank()
+			if p.at(0) == ':' {
+				p.fail(p.off, "mappings in flow sequences are not supported")
+			}
+			setParent(val, arr)
+			arr.l = append(arr.l, val)
+			p.flowNext(']')
+			if p.at(-1) == ']' {
+				return arr
+			}
+		}
+	}
+
+	obj := &jsObject{m: make(map[string]JSON), pos: pos}
+	var merges []JSON
+	for {
+		p.skipBlank()
+		if p.at(0) == '}' {
+			p.off++
+			p.merge(obj, merges)
+			return obj
+		}
+		if p.eof() {
+			p.fail(start, "unterminated flow mapping")
+		}
+
+		keyOff := p.off
+		var key string
+		merge := false
+		switch p.at(0) {
+		case '"', '\'':
+			key = p.quoted()
+		case '*':
+			key = p.keyOf(p.alias(), keyOff)
+		case '[', '{':
+			p.fail(keyOff, "mapping keys must be strings")
+		case '?':
+			p.fail(keyOff, "complex mapping keys are not supported")
+		default:
+			if key = p.plain(-1, true); key == "" {
+				p.fail(keyOff, "mapping keys must not be empty")
+			}
+			key = p.keyOf(p.resolveScalar(key, true, "", keyOff), keyOff)
+			merge = key == "<<"
+		}
+
+		p.skipBlank()
+		var val JSON
+		if p.at(0) == ':' {
+			p.off++
+			p.skipBlank()
+		}
+		if c := p.at(0); c == ',' || c == '}' {
+			val = &jsNULL{pos: p.position(p.off)}
+		} else {
+			val = p.flowNode()
+		}
+
+		if merge {
+			merges = append(merges, val)
+		} else {
+			p.setEntry(obj, key, val, keyOff)
+		}
+		p.flowNext('}')
+		if p.at(-1) == '}' {
+			p.merge(obj, merges)
+			return obj
+		}
+	}
+}
+
+// flowNext consumes ',' or the closing indicator after a flow entry.
+func (p *yamlParser) flowNext(close byte) {
+	p.skipBlank()
+	switch p.at(0) {
+	case ',', close:
+		p.off++
+	default:
+		p.fail(p.off, "expected ',' or '%c' in flow collection", close)
+	}
+}
+
+func (p *yamlParser) flowNode() JSON {
+	anchor, tag, _ := p.props()
+	p.skipBlank()
+
+	start := p.off
+	var js JSON
+	switch p.at(0) {
+	case '[', '{':
+		js = p.flowCollection()
+	case '"', '\'':
+		js = p.scalarNode(p.quoted(), false, tag, start)
+	case '*':
+		js = p.alias()
+	case ',', ']', '}':
+		js = p.scalarNode("", true, tag, start)
+	default:
+		if p.eof() {
+			p.fail(start, "unexpected end of flow collection")
+		}
+		js = p.scalarNode(p.plain(-1, true), true, tag, start)
+	}
+	p.anchor(anchor, js)
+	return js
+}
+
+// scalarNode resolves a scalar, and checks the length of it.
+func (p *yamlParser) scalarNode(text string, plain bool, tag string, off int) JSON {
+	js := p.resolveScalar(text, plain, tag, off)
+	switch v := js.(type) {
+	case *jsString:
+		p.checkString(v.s, off)
+	case *jsNumber:
+		if p.opts.maxNumber > 0 && len(v.n) > p.opts.maxNumber {
+			p.limit(LimitNumber, p.opts.maxNumber, off)
+		}
+	}
+	return js
+}
+
+// resolveScalar resolves a scalar by its tag, or by the core schema if it
+// is plain and not tagged.
+func (p *yamlParser) resolveScalar(text string, plain bool, tag string, off int) JSON {
+	pos := p.position(off)
+	switch strings.TrimPrefix(tag, "tag:yaml.org,2002:") {
+	case "!!str", "str", "!":
+		return &jsString{s: text, pos: pos}
+	case "!!null", "null":
+		return &jsNULL{pos: pos}
+	case "!!bool", "bool":
+		if js := yamlBool(text, pos); js != nil {
+			return js
+		}
+		p.fail(off, "invalid !!bool value '%v'", text)
+	case "!!int", "!!float", "int", "float":
+		if n, ok := yamlNumber(text); ok {
+			return &jsNumber{n: json.Number(n), pos: pos}
+		}
+		p.fail(off, "invalid %v value '%v'", tag, text)
+	}
+
+	if !plain {
+		return &jsString{s: text, pos: pos}
+	}
+	switch text {
+	case "", "~", "null", "Null", "NULL":
+		return &jsNULL{pos: pos}
+	}
+	if js := yamlBool(text, pos); js != nil {
+		return js
+	}
+	if n, ok := yamlNumber(text); ok {
+		return &jsNumber{n: json.Number(n), pos: pos}
+	}
+	return &jsString{s: text, pos: pos}
+}
+
+func yamlBool(text string, pos Position) JSON {
+	switch text {
+	case "true", "True", "TRUE":
+		return &jsBoolean{v: true, pos: pos}
+	case "false", "False", "FALSE":
+		return &jsBoolean{v: false, pos: pos}
+	}
+	return nil
+}
+
+// yamlNumber converts a YAML 1.2 core schema number into JSON number,
+// .inf and .nan are not numbers of JSON.
+func yamlNumber(s string) (string, bool) {
+	sign := ""
+	switch {
+	case strings.HasPrefix(s, "-"):
+		sign, s = "-", s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	if s == "" {
+		return "", false
+	}
+
+	if len(s) > 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'o') {
+		base := 16
+		if s[1] == 'o' {
+			base = 8
+		}
+		n, ok := new(big.Int).SetString(s[2:], base)
+		if !ok || n.Sign() < 0 || strings.ContainsAny(s[2:], "+-_") {
+			return "", false
+		}
+		return sign + n.String(), true
+	}
+
+	digits := func(s string) (string, string) {
+		i := 0
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		return s[:i], s[i:]
+	}
+	intPart, rest := digits(s)
+	var frac string
+	dot := strings.HasPrefix(rest, ".")
+	if dot {
+		frac, rest = digits(rest[1:])
+	}
+	if intPart == "" && frac == "" {
+		return "", false
+	}
+	var exp string
+	if strings.HasPrefix(rest, "e") || strings.HasPrefix(rest, "E") {
+		rest = rest[1:]
+		expSign := ""
+		if strings.HasPrefix(rest, "-") || strings.HasPrefix(rest, "+") {
+			expSign, rest = rest[:1], rest[1:]
+		}
+		var expDigits string
+		if expDigits, rest = digits(rest); expDigits == "" {
+			return "", false
+		}
+		exp = "e" + expSign + expDigits
+	}
+	if rest != "" {
+		return "", false
+	}
+
+	if intPart = strings.TrimLeft(intPart, "0"); intPart == "" {
+		intPart = "0"
+	}
+	n := sign + intPart
+	if frac != "" {
+		n += "." + frac
+	}
+	return n + exp, true
+}
@@ -0,0 +1,151 @@
+package jsi
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func parseYAML(t *testing.T, src string) (JSON, error) {
+	t.Helper()
+	return NewYAMLBytesParser([]byte(src)).Parse()
+}
+
+func TestYAML(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		json string
+	}{
+		// block scalars, literal
+		{"literal clip", "a: |\n  keep\n\n", `{"a": "keep\n"}`},
+		{"literal strip", "a: |-\n  keep\n\n", `{"a": "keep"}`},
+		{"literal keep", "a: |+\n  keep\n", `{"a": "keep\n"}`},
+		{"literal keep blank", "a: |+\n  keep\n\n", `{"a": "keep\n\n"}`},
+		{"literal keep followed", "a: |+\n  keep\n\nb: 1\n", `{"a": "keep\n\n", "b": 1}`},
+		{"literal clip followed", "a: |\n  x\n   y\n\nb: 1\n", `{"a": "x\n y\n", "b": 1}`},
+		{"literal indent indicator", "a: |2\n   x\n  y\n", `{"a": " x\ny\n"}`},
+		{"literal empty keep", "a: |+\n\n\nb: 1\n", `{"a": "\n\n", "b": 1}`},
+		{"literal empty", "a: |\nb: 1\n", `{"a": "", "b": 1}`},
+		{"literal crlf", "a: |\r\n  x\r\n  y\r\n", `{"a": "x\ny\n"}`},
+		{"literal comment", "a: | # text\n  x\n", `{"a": "x\n"}`},
+
+		// block scalars, folded
+		{"folded clip", "a: >\n  one\n  two\n\n  three\n", `{"a": "one two\nthree\n"}`},
+		{"folded strip", "a: >-\n  one\n  two\n", `{"a": "one two"}`},
+		{"folded keep", "a: >+\n  one\n  two\n\n", `{"a": "one two\n\n"}`},
+		{"folded more indented", "a: >\n  one\n    two\n  three\n", `{"a": "one\n  two\nthree\n"}`},
+
+		// flow collections
+		{"flow sequence", "[1, two, \"three\", [4]]", `[1, "two", "three", [4]]`},
+		{"flow mapping", "{a: 1, b: [x, y], c: {d: null}}", `{"a": 1, "b": ["x", "y"], "c": {"d": null}}`},
+		{"flow multiline", "a: [1,\n  2, 3,\n]\n", `{"a": [1, 2, 3]}`},
+		{"flow empty", "a: []\nb: {}\n", `{"a": [], "b": {}}`},
+		{"flow quoted keys", "{\"a b\": 1, 'c''d': 2}", `{"a b": 1, "c'd": 2}`},
+
+		// anchors and aliases
+		{"alias scalar", "a: &x 1\nb: *x\n", `{"a": 1, "b": 1}`},
+		{"alias mapping", "a: &x {k: v}\nb: *x\n", `{"a": {"k": "v"}, "b": {"k": "v"}}`},
+		{"alias sequence", "a: &x\n  - 1\n  - 2\nb: *x\n", `{"a": [1, 2], "b": [1, 2]}`},
+		{"alias redefined", "a: &x 1\nb: *x\nc: &x 2\nd: *x\n", `{"a": 1, "b": 1, "c": 2, "d": 2}`},
+		{"merge key", "base: &b {x: 1, y: 2}\nderived:\n  <<: *b\n  y: 3\n", `{"base": {"x": 1, "y": 2}, "derived": {"x": 1, "y": 3}}`},
+		{"merge keys", "a: &a {x: 1}\nb: &b {x: 2, y: 2}\nc:\n  <<: [*a, *b]\n", `{"a": {"x": 1}, "b": {"x": 2, "y": 2}, "c": {"x": 1, "y": 2}}`},
+
+		// scalars
+		{"core types", "- true\n- ~\n- 0x1f\n- 0o17\n- 1.5e3\n- .inf\n- \"1\"\n- 1_000\n", `[true, null, 31, 15, 1.5e3, ".inf", "1", "1_000"]`},
+		{"tags", "- !!str 1\n- !!int \"2\"\n- !custom x\n", `["1", 2, "x"]`},
+		{"quoted numeric keys", "\"200\": ok\n'201': ok\n", `{"200": "ok", "201": "ok"}`},
+		{"comments", "# head\na: 1 # tail\n# end\n", `{"a": 1}`},
+	}
+	for _, tt := range tests {
+		got, err := parseYAML(t, tt.yaml)
+		if err != nil {
+			t.Errorf("%v: %v", tt.name, err)
+			continue
+		}
+		want, err := NewBytesParser([]byte(tt.json)).Parse()
+		if err != nil {
+			t.Fatalf("%v: %v", tt.name, err)
+		}
+		if !Equal(got, want) {
+			b, _ := got.(interface{ MarshalJSON() ([]byte, error) }).MarshalJSON()
+			t.Errorf("%v: got %s, want %v", tt.name, b, tt.json)
+		}
+	}
+}
+
+func TestYAMLDocuments(t *testing.T) {
+	p := NewYAMLBytesParser([]byte("a: 1\n---\n- 2\n...\n--- 3\n"))
+	var docs []JSON
+	for {
+		js, err := p.Parse()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		docs = append(docs, js)
+	}
+	if len(docs) != 3 || docs[2].Type() != TypeNumber {
+		t.Errorf("got %d documents: %v", len(docs), docs)
+	}
+}
+
+func TestYAMLErrors(t *testing.T) {
+	tests := []struct {
+		yaml      string
+		line, col int
+		msg       string
+	}{
+		{"a: 1\na: 2\n", 2, 1, "duplicate"},
+		{"a: *none\n", 1, 4, "unknown anchor"},
+		{"a: [1, 2\n", 2, 1, ""},
+		{"a: {b: 1\n", 2, 1, ""},
+		{"a: \"x\n", 1, 4, ""},
+		{"a: |x\n  y\n", 1, 5, "block scalar header"},
+		{"a:\n  b: 1\n c: 2\n", 3, 2, ""},
+		{"[a]: 1\n", 1, 1, ""},
+		{"200: ok\n", 1, 1, "mapping keys must be strings"},
+		{"a:\n  true: 1\n", 2, 3, "mapping keys must be strings"},
+		{"{a: 1, ~: 2}\n", 1, 8, "mapping keys must be strings"},
+		{"a: &n 1\n*n : 2\n", 2, 1, "mapping keys must be strings"},
+	}
+	for _, tt := range tests {
+		_, err := parseYAML(t, tt.yaml)
+		se, ok := err.(*SyntaxError)
+		if !ok {
+			t.Errorf("%q: got %v, want *SyntaxError", tt.yaml, err)
+			continue
+		}
+		if se.Position.Line != tt.line || se.Position.Column != tt.col {
+			t.Errorf("%q: error %v at %v, want %d:%d", tt.yaml, se, se.Position, tt.line, tt.col)
+		}
+		if !strings.Contains(se.Error(), tt.msg) {
+			t.Errorf("%q: got %v, want %q", tt.yaml, se, tt.msg)
+		}
+	}
+}
+
+func TestYAMLAliasBomb(t *testing.T) {
+	src := "a: &a [x, x, x, x, x, x, x, x, x, x]\n"
+	for c := 'b'; c <= 'j'; c++ {
+		prev := string(c - 1)
+		src += string(c) + ": &" + string(c) + " [*" + prev + ", *" + prev + ", *" + prev + ", *" + prev +
+			", *" + prev + ", *" + prev + ", *" + prev + ", *" + prev + ", *" + prev + ", *" + prev + "]\n"
+	}
+	if _, err := parseYAML(t, src); err == nil {
+		t.Errorf("alias bomb parsed")
+	}
+}
+
+func TestYAMLPositions(t *testing.T) {
+	js, err := parseYAML(t, "a:\n  - x\n  - {b: 2}\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	arr := js.(Object).Index("a").(Array)
+	if pos := PositionOf(arr.Index(1)); pos.Line != 3 || pos.Column != 5 {
+		t.Errorf("got %v, want 3:5", pos)
+	}
+}
@@ -0,0 +1,46 @@
+package jsonschema_test
+
+import (
+	"strings"
+	"testing"
+
+	schema "github.com/eachain/jsonschema"
+	_ "github.com/eachain/jsonschema/draft04"
+	_ "github.com/eachain/jsonschema/draft06"
+	_ "github.com/eachain/jsonschema/draft07"
+	_ "github.com/eachain/jsonschema/draft201909"
+	_ "github.com/eachain/jsonschema/draft202012"
+	"github.com/eachain/jsonschema/jsi"
+)
+
+func parse(t *testing.T, s string) jsi.JSON {
+	t.Helper()
+	js, err := jsi.NewBytesParser([]byte(s)).Parse()
+	if err != nil {
+		t.Fatalf("parse %s: %v", s, err)
+	}
+	return js
+}
+
+// compile compiles schema s, failing t if it is invalid.
+func compile(t *testing.T, s string, drafts ...string) *schema.Schema {
+	t.Helper()
+	sc, result := schema.Compile(parse(t, s), drafts...)
+	if !result.Valid() {
+		t.Fatalf("compile %s: %v", s, result.Error())
+	}
+	return sc
+}
+
+// hasWarning reports whether a warning of result contains msg.
+func hasWarning(result *schema.Result, msg string) bool {
+	if result == nil {
+		return false
+	}
+	for _, w := range result.Warnings {
+		if strings.Contains(w.Msg, msg) {
+			return true
+		}
+	}
+	return false
+}
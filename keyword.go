@@ -1,6 +1,7 @@
 package jsonschema
 
 import (
+	"sort"
 	"sync"
 
 	"github.com/eachain/jsonschema/jsi"
@@ -56,6 +57,7 @@ func supportDrafts() []string {
 	for draft := range keywordsOf {
 		drafts = append(drafts, draft)
 	}
+	sort.Strings(drafts)
 	return drafts
 }
 
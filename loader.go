@@ -0,0 +1,261 @@
+package jsonschema
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eachain/jsonschema/jsi"
+)
+
+// Loader loads the schema document at uri, which is referenced by $ref
+// and can't be resolved in compiled schemas. uri has no fragment.
+//
+// Loaders of files and urls parse documents named *.yaml or *.yml as YAML.
+type Loader interface {
+	Load(uri string) (jsi.JSON, error)
+}
+
+type LoaderFunc func(uri string) (jsi.JSON, error)
+
+func (fn LoaderFunc) Load(uri string) (jsi.JSON, error) {
+	return fn(uri)
+}
+
+// ErrNotFound is returned by loaders if uri is not served by them,
+// Loaders tries the next one then.
+var ErrNotFound = errors.New("schema not found")
+
+// DefaultLoader is used by Compile, it loads http(s):// uris.
+// Local files are not loaded, so an untrusted schema can't read them by
+// $ref; use DirLoader for files under a directory, or FileLoader for any
+// file:// uri if schemas are trusted. Set it to a loader without
+// HTTPLoader to disable network fetching.
+var DefaultLoader Loader = Loaders{HTTPLoader{}}
+
+// NoLoader loads nothing, any unresolved reference fails compiling.
+var NoLoader Loader = LoaderFunc(func(uri string) (jsi.JSON, error) {
+	return nil, fmt.Errorf("%w: %v", ErrNotFound, uri)
+})
+
+// Loaders loads uri by the first loader serving it.
+type Loaders []Loader
+
+func (ls Loaders) Load(uri string) (jsi.JSON, error) {
+	for _, l := range ls {
+		js, err := l.Load(uri)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		return js, err
+	}
+	return nil, fmt.Errorf("%w: %v", ErrNotFound, uri)
+}
+
+// MemoryLoader serves schema documents in memory by uri.
+type MemoryLoader map[string][]byte
+
+func (ml MemoryLoader) Load(uri string) (jsi.JSON, error) {
+	doc, ok := ml[uri]
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrNotFound, uri)
+	}
+	return jsi.NewBytesParser(doc).Parse()
+}
+
+// DirLoader maps uri prefixes to local directories, eg.
+// "https://example.com/schemas/" to "./schemas/".
+type DirLoader map[string]string
+
+func (dl DirLoader) Load(uri string) (jsi.JSON, error) {
+	prefix := ""
+	for p := range dl {
+		if strings.HasPrefix(uri, p) && len(p) > len(prefix) {
+			prefix = p
+		}
+	}
+	if prefix == "" {
+		return nil, fmt.Errorf("%w: %v", ErrNotFound, uri)
+	}
+
+	name, err := relativeName(uri, prefix)
+	if err != nil {
+		return nil, err
+	}
+	return parseFile(filepath.Join(dl[prefix], filepath.FromSlash(name)))
+}
+
+// FSLoader loads uris with Prefix from FS, eg. an embed.FS,
+// the rest of uri is the file name in FS.
+type FSLoader struct {
+	Prefix string
+	FS     fs.FS
+}
+
+func (fl FSLoader) Load(uri string) (jsi.JSON, error) {
+	if !strings.HasPrefix(uri, fl.Prefix) {
+		return nil, fmt.Errorf("%w: %v", ErrNotFound, uri)
+	}
+	name, err := relativeName(uri, fl.Prefix)
+	if err != nil {
+		return nil, err
+	}
+	fp, err := fl.FS.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	if jsi.IsYAMLFile(name) {
+		defer fp.Close()
+		return jsi.NewYAMLParser(fp).Parse()
+	}
+	return jsi.NewReadCloserParser(fp).Parse()
+}
+
+// parseFile parses a JSON file, or a YAML file by its extension.
+func parseFile(name string) (jsi.JSON, error) {
+	if jsi.IsYAMLFile(name) {
+		return jsi.NewYAMLFileParser(name).Parse()
+	}
+	return jsi.NewFileParser(name).Parse()
+}
+
+func relativeName(uri, prefix string) (string, error) {
+	name := strings.TrimPrefix(strings.TrimPrefix(uri, prefix), "/")
+	if !fs.ValidPath(name) {
+		return "", fmt.Errorf("invalid schema path: %v", uri)
+	}
+	return name, nil
+}
+
+// FileLoader loads file:// uris, any file readable by the process,
+// it should be used only with trusted schemas.
+type FileLoader struct{}
+
+func (FileLoader) Load(uri string) (jsi.JSON, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "file" {
+		return nil, fmt.Errorf("%w: %v", ErrNotFound, uri)
+	}
+	return parseFile(filepath.FromSlash(path.Clean(u.Path)))
+}
+
+// HTTPLoader loads http:// and https:// uris.
+//
+// Allow restricts uris loaded to those under any of the prefixes:
+// scheme and host must be the same, and the path must be the path of
+// the prefix or below it, eg. "https://example.com/schemas" allows
+// "https://example.com/schemas/a.json" but neither
+// "https://example.com/schemas-old/a.json" nor "https://example.com.evil.net/".
+//
+// Responses larger than MaxBytes fail loading with a *jsi.LimitError.
+type HTTPLoader struct {
+	Client   *http.Client    // http.Client with 10s timeout if nil
+	Allow    []string        // allowed uri prefixes, any if empty
+	MaxBytes int64           // response size limit, DefaultHTTPMaxBytes if 0
+	Context  context.Context // context of requests, context.Background() if nil
+}
+
+// DefaultHTTPMaxBytes is the response size limit of HTTPLoader by default.
+const DefaultHTTPMaxBytes = 16 << 20
+
+var defaultHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+func (hl HTTPLoader) Load(uri string) (jsi.JSON, error) {
+	if !strings.HasPrefix(uri, "http://") && !strings.HasPrefix(uri, "https://") {
+		return nil, fmt.Errorf("%w: %v", ErrNotFound, uri)
+	}
+	if len(hl.Allow) > 0 {
+		allowed := false
+		for _, prefix := range hl.Allow {
+			if underPrefix(uri, prefix) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return nil, fmt.Errorf("%w: %v not allowed", ErrNotFound, uri)
+		}
+	}
+
+	client := hl.Client
+	if client == nil {
+		client = defaultHTTPClient
+	}
+	ctx := hl.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	limit := hl.MaxBytes
+	if limit == 0 {
+		limit = DefaultHTTPMaxBytes
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, io.LimitReader(resp.Body, limit))
+		resp.Body.Close()
+		return nil, fmt.Errorf("HTTP GET %v: %v", uri, resp.Status)
+	}
+	if u, err := url.Parse(uri); err == nil && jsi.IsYAMLFile(u.Path) {
+		defer resp.Body.Close()
+		return jsi.NewYAMLParser(resp.Body, jsi.WithMaxBytes(limit)).Parse()
+	}
+	return jsi.NewReadCloserParser(resp.Body, jsi.WithMaxBytes(limit)).Parse()
+}
+
+// underPrefix reports whether uri has the scheme and host of prefix,
+// and a path which is the path of prefix or below it.
+func underPrefix(uri, prefix string) bool {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return false
+	}
+	p, err := url.Parse(prefix)
+	if err != nil {
+		return false
+	}
+	if !strings.EqualFold(u.Scheme, p.Scheme) || !strings.EqualFold(u.Host, p.Host) {
+		return false
+	}
+	// cleaned, so "/schemas/../private" isn't under "/schemas"
+	dir := strings.TrimSuffix(path.Clean("/"+p.EscapedPath()), "/")
+	name := path.Clean("/" + u.EscapedPath())
+	return name == dir || strings.HasPrefix(name, dir+"/")
+}
+
+// CacheLoader caches schema documents loaded by Loader,
+// documents failed loading are not cached.
+type CacheLoader struct {
+	Loader Loader
+	cache  sync.Map
+}
+
+func (cl *CacheLoader) Load(uri string) (jsi.JSON, error) {
+	if js, ok := cl.cache.Load(uri); ok {
+		return js.(jsi.JSON), nil
+	}
+	js, err := cl.Loader.Load(uri)
+	if err != nil {
+		return nil, err
+	}
+	cl.cache.Store(uri, js)
+	return js, nil
+}
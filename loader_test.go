@@ -0,0 +1,153 @@
+package jsonschema_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	schema "github.com/eachain/jsonschema"
+	"github.com/eachain/jsonschema/jsi"
+)
+
+func TestLoaders(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.json"), []byte(`{"type": "integer"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "b.yaml"), []byte("type: string\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		loader schema.Loader
+		uri    string
+		typ    string
+	}{
+		{schema.MemoryLoader{"https://example.com/a.json": []byte(`{"type": "integer"}`)}, "https://example.com/a.json", "integer"},
+		{schema.DirLoader{"https://example.com/schemas/": dir}, "https://example.com/schemas/a.json", "integer"},
+		{schema.DirLoader{"https://example.com/schemas/": dir}, "https://example.com/schemas/b.yaml", "string"},
+		{schema.FSLoader{Prefix: "https://example.com/", FS: fstest.MapFS{"c.json": {Data: []byte(`{"type": "null"}`)}}}, "https://example.com/c.json", "null"},
+		{schema.FileLoader{}, "file://" + filepath.ToSlash(filepath.Join(dir, "a.json")), "integer"},
+		{schema.Loaders{schema.NoLoader, schema.MemoryLoader{"urn:x": []byte(`{"type": "boolean"}`)}}, "urn:x", "boolean"},
+	}
+	for _, tt := range tests {
+		js, err := tt.loader.Load(tt.uri)
+		if err != nil {
+			t.Errorf("load %v: %v", tt.uri, err)
+			continue
+		}
+		if typ, _ := js.(jsi.Object).Index("type").(jsi.String); typ == nil || typ.Value() != tt.typ {
+			t.Errorf("load %v: got %v, want type %v", tt.uri, typ, tt.typ)
+		}
+	}
+
+	if _, err := (schema.MemoryLoader{}).Load("urn:none"); !errors.Is(err, schema.ErrNotFound) {
+		t.Errorf("load unknown uri: got %v, want ErrNotFound", err)
+	}
+	if _, err := (schema.DirLoader{"https://example.com/": dir}).Load("https://example.com/../a.json"); err == nil {
+		t.Errorf("load path out of dir: no error")
+	}
+}
+
+func TestHTTPLoaderAllow(t *testing.T) {
+	var requested []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		w.Write([]byte(`{"type": "integer"}`))
+	}))
+	defer srv.Close()
+
+	hl := schema.HTTPLoader{Client: srv.Client(), Allow: []string{srv.URL + "/schemas"}}
+	for _, path := range []string{"/schemas", "/schemas/a.json", "/schemas/x/b.json"} {
+		if _, err := hl.Load(srv.URL + path); err != nil {
+			t.Errorf("load %v: %v", path, err)
+		}
+	}
+	for _, uri := range []string{
+		srv.URL + "/schemas-old/a.json",
+		srv.URL + "/schemas/../private.json",
+		srv.URL + "/",
+		strings.Replace(srv.URL, "127.0.0.1", "127.0.0.1.evil.net", 1) + "/schemas/a.json",
+		"https://" + strings.TrimPrefix(srv.URL, "http://") + "/schemas/a.json",
+	} {
+		if _, err := hl.Load(uri); !errors.Is(err, schema.ErrNotFound) {
+			t.Errorf("load %v: got %v, want not allowed", uri, err)
+		}
+	}
+	if len(requested) != 3 {
+		t.Errorf("requested %v", requested)
+	}
+}
+
+func TestDefaultLoaderNoFiles(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "a.json")
+	if err := os.WriteFile(name, []byte(`{"type": "integer"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	uri := "file://" + filepath.ToSlash(name)
+	if _, err := schema.DefaultLoader.Load(uri); !errors.Is(err, schema.ErrNotFound) {
+		t.Errorf("load %v: got %v, want ErrNotFound", uri, err)
+	}
+}
+
+func TestHTTPLoaderLimits(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"description": "` + strings.Repeat("x", 1<<10) + `"}`))
+	}))
+	defer srv.Close()
+
+	hl := schema.HTTPLoader{Client: srv.Client(), MaxBytes: 1 << 9}
+	_, err := hl.Load(srv.URL + "/a.json")
+	var le *jsi.LimitError
+	if !errors.As(err, &le) || le.Limit != jsi.LimitBytes {
+		t.Errorf("got %v, want *jsi.LimitError of input size", err)
+	}
+
+	hl.MaxBytes = 0
+	if _, err = hl.Load(srv.URL + "/a.json"); err != nil {
+		t.Errorf("load under default limit: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	hl.Context = ctx
+	if _, err = hl.Load(srv.URL + "/a.json"); !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v, want context.Canceled", err)
+	}
+}
+
+func TestCompileWithLoader(t *testing.T) {
+	loader := schema.MemoryLoader{"https://example.com/item.json": []byte(`{"type": "integer"}`)}
+	sc, result := schema.CompileWithLoader(loader, parse(t, `{"items": {"$ref": "https://example.com/item.json"}}`), "draft-07")
+	if !result.Valid() {
+		t.Fatalf("compile: %v", result.Error())
+	}
+	if !sc.Validate(parse(t, `[1, 2]`)).Valid() || sc.Validate(parse(t, `["a"]`)).Valid() {
+		t.Errorf("remote reference not applied")
+	}
+
+	_, result = schema.CompileWithLoader(schema.NoLoader, parse(t, `{"$ref": "https://example.com/none.json"}`), "draft-07")
+	if result.Valid() {
+		t.Errorf("unresolved remote reference compiled")
+	}
+
+	// an unresolved fragment of a document without uri isn't loaded
+	loaded := false
+	spy := schema.LoaderFunc(func(uri string) (jsi.JSON, error) {
+		loaded = true
+		return nil, schema.ErrNotFound
+	})
+	_, result = schema.CompileWithLoader(spy, parse(t, `{"$ref": "#/definitions/none"}`), "draft-07")
+	if result.Valid() || !strings.Contains(result.Error(), "can't resolve reference #/definitions/none") {
+		t.Errorf("got %v, want can't resolve", result.Error())
+	}
+	if loaded {
+		t.Errorf("loader called for a local fragment")
+	}
+}
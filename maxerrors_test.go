@@ -0,0 +1,59 @@
+package jsonschema_test
+
+import (
+	"testing"
+
+	schema "github.com/eachain/jsonschema"
+)
+
+func TestMaxErrors(t *testing.T) {
+	sc := compile(t, `{
+		"items": {"type": "integer"},
+		"minItems": 10,
+		"anyOf": [{"type": "string"}, {"maxItems": 1}]
+	}`, "draft-07")
+	doc := parse(t, `["a", "b", "c"]`)
+
+	tests := []struct {
+		opts []schema.ValidateOption
+		n    int
+	}{
+		{nil, 5},
+		{[]schema.ValidateOption{schema.WithMaxErrors(0)}, 5},
+		{[]schema.ValidateOption{schema.WithMaxErrors(100)}, 5},
+		{[]schema.ValidateOption{schema.WithMaxErrors(2)}, 2},
+		{[]schema.ValidateOption{schema.WithFailFast()}, 1},
+		{[]schema.ValidateOption{schema.WithFailFast(), schema.WithAnnotations()}, 1},
+	}
+	for i, tt := range tests {
+		result := sc.Validate(doc, tt.opts...)
+		if len(result.Errors) != tt.n {
+			t.Errorf("%d: got %d errors, want %d: %v", i, len(result.Errors), tt.n, result.Error())
+		}
+		if len(result.Annotations) > 0 {
+			t.Errorf("%d: annotations of invalid instance: %v", i, result.Annotations)
+		}
+	}
+}
+
+func TestFailFastValidity(t *testing.T) {
+	// branches stopped early never change whether the instance is valid
+	tests := []struct {
+		schema, instance string
+	}{
+		{`{"anyOf": [{"type": "string", "minLength": 2}, {"type": "array"}]}`, `[]`},
+		{`{"oneOf": [{"minItems": 1, "items": {"type": "string"}}, {"maxItems": 5}]}`, `[]`},
+		{`{"oneOf": [{"items": {"type": "string"}}, {"items": {"type": "integer"}}]}`, `[1, "a"]`},
+		{`{"not": {"items": {"type": "string"}, "minItems": 2}}`, `[1, 2]`},
+		{`{"if": {"required": ["a", "b"]}, "then": {"required": ["c"]}}`, `{"a": 1}`},
+		{`{"contains": {"type": "integer", "minimum": 5}}`, `[1, "a", 7]`},
+		{`{"allOf": [{"minimum": 5}, {"multipleOf": 2}]}`, `3`},
+	}
+	for _, tt := range tests {
+		sc := compile(t, tt.schema, "draft-07")
+		want := sc.Validate(parse(t, tt.instance)).Valid()
+		if got := sc.Validate(parse(t, tt.instance), schema.WithFailFast()).Valid(); got != want {
+			t.Errorf("%v with %v: got valid %v, want %v", tt.schema, tt.instance, got, want)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package jsonschema
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"strings"
+	"sync"
+
+	"github.com/eachain/jsonschema/jsi"
+)
+
+// metaFS embeds the official meta-schemas, named by their uris
+// without scheme, eg. "meta/json-schema.org/draft-07/schema.json".
+//
+//go:embed meta
+var metaFS embed.FS
+
+// MetaLoader loads the official meta-schemas embedded in the module,
+// eg. "http://json-schema.org/draft-04/schema#". Meta-schema uris are
+// always resolved by it before the loader of compiling.
+var MetaLoader Loader = LoaderFunc(loadMeta)
+
+func loadMeta(uri string) (jsi.JSON, error) {
+	name := strings.TrimSuffix(uri, "#")
+	switch {
+	case strings.HasPrefix(name, "http://"):
+		name = strings.TrimPrefix(name, "http://")
+	case strings.HasPrefix(name, "https://"):
+		name = strings.TrimPrefix(name, "https://")
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrNotFound, uri)
+	}
+	if !strings.HasPrefix(name, "json-schema.org/") || !fs.ValidPath(name) {
+		return nil, fmt.Errorf("%w: %v", ErrNotFound, uri)
+	}
+
+	doc, err := metaFS.ReadFile("meta/" + name + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotFound, uri)
+	}
+	return jsi.NewBytesParser(doc).Parse()
+}
+
+var (
+	metaURIMu sync.RWMutex
+	metaURIOf map[string]string
+
+	// metaMu is held while compiling a meta-schema, which looks up
+	// metaURIOf, so it is not guarded by metaMu
+	metaMu       sync.Mutex
+	metaSchemaOf map[string]*Schema
+)
+
+// RegisterMetaSchema registers uri as the meta-schema of draft,
+// which is called by drafts in init.
+func RegisterMetaSchema(draft string, uri string) {
+	metaURIMu.Lock()
+	if metaURIOf == nil {
+		metaURIOf = make(map[string]string)
+	}
+	metaURIOf[draft] = uri
+	metaURIMu.Unlock()
+
+	metaMu.Lock()
+	delete(metaSchemaOf, draft)
+	metaMu.Unlock()
+}
+
+// draftOfMetaURI returns the draft registered with meta-schema uri,
+// or "" if none. An empty fragment of uri is ignored.
+func draftOfMetaURI(uri string) string {
+	metaURIMu.RLock()
+	defer metaURIMu.RUnlock()
+	uri = strings.TrimSuffix(uri, "#")
+	for draft, meta := range metaURIOf {
+		if strings.TrimSuffix(meta, "#") == uri {
+			return draft
+		}
+	}
+	return ""
+}
+
+// metaURIOfDraft returns the meta-schema uri registered by draft.
+func metaURIOfDraft(draft string) string {
+	metaURIMu.RLock()
+	defer metaURIMu.RUnlock()
+	return metaURIOf[draft]
+}
+
+// MetaSchema returns the compiled meta-schema of draft.
+func MetaSchema(draft string) (*Schema, *Result) {
+	metaMu.Lock()
+	defer metaMu.Unlock()
+
+	if sc := metaSchemaOf[draft]; sc != nil {
+		return sc, nil
+	}
+
+	uri := metaURIOfDraft(draft)
+	if uri == "" {
+		return nil, WithError(Error{
+			Field: draft,
+			Type:  TypeString,
+			Value: draft,
+			Msg:   MsgMetaSchemaNotFound,
+		})
+	}
+	js, err := loadMeta(uri)
+	if err != nil {
+		return nil, WithError(Error{
+			Field: uri,
+			Type:  TypeString,
+			Value: uri,
+			Msg:   "load meta-schema: " + err.Error(),
+		})
+	}
+
+	sc, result := CompileWithLoader(NoLoader, js, draft)
+	if !result.Valid() {
+		return nil, result
+	}
+	if metaSchemaOf == nil {
+		metaSchemaOf = make(map[string]*Schema)
+	}
+	metaSchemaOf[draft] = sc
+	return sc, nil
+}
+
+// ValidateSchema validates schema js against the meta-schema of draft.
+func ValidateSchema(js jsi.JSON, draft string, opts ...ValidateOption) *Result {
+	meta, result := MetaSchema(draft)
+	if !result.Valid() {
+		return result
+	}
+	return meta.Validate(js, opts...)
+}
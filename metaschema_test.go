@@ -0,0 +1,90 @@
+package jsonschema_test
+
+import (
+	"errors"
+	"testing"
+
+	schema "github.com/eachain/jsonschema"
+)
+
+var drafts = []string{"draft-04", "draft-06", "draft-07", "draft-201909", "draft-202012"}
+
+func TestMetaSchema(t *testing.T) {
+	for _, draft := range drafts {
+		sc, result := schema.MetaSchema(draft)
+		if !result.Valid() {
+			t.Fatalf("%v: %v", draft, result.Error())
+		}
+		if again, _ := schema.MetaSchema(draft); again != sc {
+			t.Errorf("%v: meta-schema compiled again", draft)
+		}
+		if sc.Draft() != draft {
+			t.Errorf("%v: compiled as %v", draft, sc.Draft())
+		}
+	}
+
+	if _, result := schema.MetaSchema("draft-00"); result.Valid() {
+		t.Errorf("meta-schema of unknown draft compiled")
+	}
+}
+
+func TestValidateSchema(t *testing.T) {
+	valid := `{"type": "object", "properties": {"a": {"type": "string", "minLength": 1}}}`
+	invalid := []string{
+		`{"type": "unknown"}`,
+		`{"minLength": -1}`,
+		`{"properties": {"a": 1}}`,
+		`{"required": "a"}`,
+	}
+	for _, draft := range drafts {
+		if result := schema.ValidateSchema(parse(t, valid), draft); !result.Valid() {
+			t.Errorf("%v: %v", draft, result.Error())
+		}
+		for _, s := range invalid {
+			if schema.ValidateSchema(parse(t, s), draft).Valid() {
+				t.Errorf("%v: %v validated", draft, s)
+			}
+		}
+	}
+
+	// keywords are checked against their own draft
+	if !schema.ValidateSchema(parse(t, `{"minimum": 1, "exclusiveMinimum": true}`), "draft-04").Valid() {
+		t.Errorf("draft-04: boolean exclusiveMinimum failed")
+	}
+	if schema.ValidateSchema(parse(t, `{"minimum": 1, "exclusiveMinimum": true}`), "draft-07").Valid() {
+		t.Errorf("draft-07: boolean exclusiveMinimum validated")
+	}
+}
+
+func TestMetaLoader(t *testing.T) {
+	for _, uri := range []string{
+		"http://json-schema.org/draft-04/schema#",
+		"http://json-schema.org/draft-07/schema",
+		"https://json-schema.org/draft/2020-12/schema",
+		"https://json-schema.org/draft/2019-09/meta/applicator",
+	} {
+		if _, err := schema.MetaLoader.Load(uri); err != nil {
+			t.Errorf("%v: %v", uri, err)
+		}
+	}
+	for _, uri := range []string{
+		"https://example.com/schema",
+		"https://json-schema.org/draft/2020-12/none",
+		"https://json-schema.org/../schema",
+		"file:///json-schema.org/draft-07/schema",
+	} {
+		if _, err := schema.MetaLoader.Load(uri); !errors.Is(err, schema.ErrNotFound) {
+			t.Errorf("%v: got %v, want ErrNotFound", uri, err)
+		}
+	}
+
+	// references to meta-schemas never use the loader
+	sc, result := schema.CompileWithLoader(schema.NoLoader,
+		parse(t, `{"$ref": "http://json-schema.org/draft-07/schema#"}`), "draft-07")
+	if !result.Valid() {
+		t.Fatalf("compile: %v", result.Error())
+	}
+	if sc.Validate(parse(t, `{"type": 1}`)).Valid() {
+		t.Errorf("invalid schema validated by meta-schema referred to")
+	}
+}
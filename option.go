@@ -0,0 +1,107 @@
+package jsonschema
+
+import "context"
+
+type ValidateOption func(*validateOptions)
+
+type validateOptions struct {
+	annotating bool
+	catalog    Catalog
+	defaults   bool
+	coercing   bool
+	removing   RemoveMode
+	context    context.Context // set by ValidateContext
+	maxErrors  int
+}
+
+func newValidateOptions(opts []ValidateOption) *validateOptions {
+	o := new(validateOptions)
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithAnnotations collects annotations into Result.Annotations.
+func WithAnnotations() ValidateOption {
+	return func(o *validateOptions) {
+		o.annotating = true
+	}
+}
+
+// WithCatalog renders Msg of errors by catalog, eg. ChineseCatalog.
+func WithCatalog(c Catalog) ValidateOption {
+	return func(o *validateOptions) {
+		o.catalog = c
+	}
+}
+
+// WithDefaults fills in missing members of objects by the default of their
+// subschemas in 'properties', and missing items of arrays by those in
+// 'prefixItems' or array form 'items', before validating them.
+// Defaults in subschemas of anyOf, oneOf, not, if and contains are ignored,
+// as their failure doesn't fail the instance. It takes effect in Apply.
+func WithDefaults() ValidateOption {
+	return func(o *validateOptions) {
+		o.defaults = true
+	}
+}
+
+// WithCoerceTypes converts a value to the type expected by sibling 'type'
+// if it is none of the types, eg. "42" to 42 for integer:
+//
+//	string:          from number, boolean, or null as ""
+//	number, integer: from numeric string, boolean as 1 or 0, or null as 0
+//	boolean:         from "true", "false", 1, 0, or null as false
+//	null:            from "", 0 or false
+//	array:           from any other than object, as the only item
+//
+// The first of types it can be converted to is taken. Converted values
+// are reported in Result.Coercions. Like WithDefaults, values are not
+// converted in subschemas of anyOf, oneOf, not, if and contains.
+// It takes effect in Apply.
+func WithCoerceTypes() ValidateOption {
+	return func(o *validateOptions) {
+		o.coercing = true
+	}
+}
+
+// RemoveMode tells which members of objects are removed by WithRemoveAdditional.
+type RemoveMode string
+
+const (
+	// RemoveAll removes members not declared by 'properties' or
+	// 'patternProperties', in schemas with 'properties' or 'additionalProperties'.
+	RemoveAll RemoveMode = "all"
+	// RemoveFailing removes members failing 'additionalProperties',
+	// instead of reporting errors.
+	RemoveFailing RemoveMode = "failing"
+	// RemoveDeclaredFalse removes members not declared by 'properties' or
+	// 'patternProperties', in schemas with 'additionalProperties' false.
+	RemoveDeclaredFalse RemoveMode = "declared-false"
+)
+
+// WithRemoveAdditional removes additional members of objects as mode,
+// before validating them. Like WithDefaults, members are not removed in
+// subschemas of anyOf, oneOf, not, if and contains, but they are in each
+// subschema of allOf, where a member declared by only some of them may be
+// removed by the others. It takes effect in Apply.
+func WithRemoveAdditional(mode RemoveMode) ValidateOption {
+	return func(o *validateOptions) {
+		o.removing = mode
+	}
+}
+
+// WithMaxErrors stops validation once n errors are found, so rejecting
+// a large invalid instance gets cheaper. Result has at most n errors,
+// which are not necessarily the first n reported without the option.
+func WithMaxErrors(n int) ValidateOption {
+	return func(o *validateOptions) {
+		o.maxErrors = n
+	}
+}
+
+// WithFailFast stops validation at the first error, as WithMaxErrors(1).
+func WithFailFast() ValidateOption {
+	return WithMaxErrors(1)
+}
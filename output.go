@@ -0,0 +1,223 @@
+package jsonschema
+
+import (
+	"sort"
+	"strings"
+)
+
+// OutputFormat is one of the standard output formats of validation results,
+// see https://json-schema.org/draft/2020-12/json-schema-core#name-output-formatting.
+type OutputFormat string
+
+const (
+	// FlagOutput reports only whether the instance is valid.
+	FlagOutput OutputFormat = "flag"
+	// BasicOutput reports a flat list of errors, or annotations if valid.
+	BasicOutput OutputFormat = "basic"
+	// DetailedOutput reports errors nested by keyword location,
+	// units having a single child are condensed.
+	DetailedOutput OutputFormat = "detailed"
+	// VerboseOutput reports errors nested by keyword location,
+	// every level of the schema is kept.
+	VerboseOutput OutputFormat = "verbose"
+)
+
+// OutputUnit is a node of the standard output structure,
+// which can be marshaled by encoding/json directly.
+type OutputUnit struct {
+	Valid                   bool          `json:"valid"`
+	KeywordLocation         string        `json:"keywordLocation"`
+	AbsoluteKeywordLocation string        `json:"absoluteKeywordLocation,omitempty"`
+	InstanceLocation        string        `json:"instanceLocation"`
+	Error                   string        `json:"error,omitempty"`
+	Annotation              interface{}   `json:"annotation,omitempty"`
+	Errors                  []*OutputUnit `json:"errors,omitempty"`
+	Annotations             []*OutputUnit `json:"annotations,omitempty"`
+}
+
+// Output converts validation result into the output structure of format,
+// a nil result is valid. Annotations are reported only if collected.
+func (r *Result) Output(format OutputFormat) *OutputUnit {
+	root := &OutputUnit{Valid: r.Valid()}
+	if format == FlagOutput || r == nil {
+		return root
+	}
+
+	var units []*OutputUnit
+	if root.Valid {
+		for _, a := range r.Annotations {
+			units = append(units, &OutputUnit{
+				Valid:                   true,
+				KeywordLocation:         outputPointer(a.KeywordLocation),
+				AbsoluteKeywordLocation: outputURI(a.AbsoluteKeywordLocation),
+				InstanceLocation:        outputPointer(a.Field),
+				Annotation:              a.Value,
+			})
+		}
+	} else {
+		units = errorUnits(units, r.Errors)
+	}
+
+	if format == BasicOutput {
+		root.add(units)
+		return root
+	}
+
+	tree := &outputNode{}
+	for _, u := range units {
+		tree.insert(u)
+	}
+	tree.unit = root
+	tree.build(format == DetailedOutput)
+	return root
+}
+
+// errorUnits appends units of errs and their causes, which are nested
+// under the keyword location of errors they cause.
+func errorUnits(units []*OutputUnit, errs []Error) []*OutputUnit {
+	for _, e := range errs {
+		units = append(units, &OutputUnit{
+			KeywordLocation:         outputPointer(e.KeywordLocation),
+			AbsoluteKeywordLocation: outputURI(e.AbsoluteKeywordLocation),
+			InstanceLocation:        outputPointer(e.Field),
+			Error:                   e.Msg,
+		})
+		units = errorUnits(units, e.Causes)
+	}
+	return units
+}
+
+func (u *OutputUnit) add(units []*OutputUnit) {
+	for _, c := range units {
+		if c.Valid {
+			u.Annotations = append(u.Annotations, c)
+		} else {
+			u.Errors = append(u.Errors, c)
+		}
+	}
+}
+
+// outputNode groups output units by keyword location.
+type outputNode struct {
+	unit     *OutputUnit
+	leaves   []*OutputUnit // units reported at the keyword location
+	children map[string]*outputNode
+}
+
+func (n *outputNode) insert(u *OutputUnit) {
+	node := n
+	var loc string
+	for _, seg := range splitPointer(u.KeywordLocation) {
+		loc += "/" + seg
+		child := node.children[seg]
+		if child == nil {
+			child = &outputNode{unit: &OutputUnit{Valid: true, KeywordLocation: loc}}
+			if node.children == nil {
+				node.children = make(map[string]*outputNode)
+			}
+			node.children[seg] = child
+		}
+		node = child
+	}
+	node.leaves = append(node.leaves, u)
+}
+
+// build fills the unit of node with its descendants and returns
+// the unit standing for node, which may be condensed into its only child.
+func (n *outputNode) build(condense bool) *OutputUnit {
+	keys := make([]string, 0, len(n.children))
+	for key := range n.children {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	units := make([]*OutputUnit, 0, len(n.leaves)+len(keys))
+	units = append(units, n.leaves...)
+	for _, key := range keys {
+		units = append(units, n.children[key].build(condense))
+	}
+
+	if n.unit.KeywordLocation != "" && len(units) == 1 &&
+		(condense || units[0].KeywordLocation == n.unit.KeywordLocation) {
+		return units[0]
+	}
+
+	u := n.unit
+	var instances []string
+	for _, c := range units {
+		if !c.Valid {
+			u.Valid = false
+		}
+		instances = append(instances, c.InstanceLocation)
+		if u.AbsoluteKeywordLocation == "" {
+			u.AbsoluteKeywordLocation = parentURI(c, u.KeywordLocation)
+		}
+	}
+	if u.KeywordLocation != "" {
+		u.InstanceLocation = commonPointer(instances)
+	}
+	u.add(units)
+	return u
+}
+
+// parentURI returns the absolute location of keyword location loc, which
+// is a parent of unit c, only if no reference between them.
+func parentURI(c *OutputUnit, loc string) string {
+	if c.AbsoluteKeywordLocation == "" {
+		return ""
+	}
+	suffix := strings.TrimPrefix(c.KeywordLocation, loc)
+	if !strings.HasSuffix(c.AbsoluteKeywordLocation, suffix) {
+		return ""
+	}
+	for _, seg := range splitPointer(suffix) {
+		if seg == "$ref" || seg == "$recursiveRef" || seg == "$dynamicRef" {
+			return ""
+		}
+	}
+	return strings.TrimSuffix(c.AbsoluteKeywordLocation, suffix)
+}
+
+// outputPointer converts a uri fragment like "#/a/b" into
+// a JSON pointer like "/a/b".
+func outputPointer(field string) string {
+	return strings.TrimPrefix(field, "#")
+}
+
+// outputURI returns "" if there's no absolute uri.
+func outputURI(uri string) string {
+	if strings.HasPrefix(uri, "#") {
+		return ""
+	}
+	return uri
+}
+
+func splitPointer(ptr string) []string {
+	if ptr == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimPrefix(ptr, "/"), "/")
+}
+
+func commonPointer(ptrs []string) string {
+	if len(ptrs) == 0 {
+		return ""
+	}
+	common := splitPointer(ptrs[0])
+	for _, ptr := range ptrs[1:] {
+		segs := splitPointer(ptr)
+		if len(segs) < len(common) {
+			common = common[:len(segs)]
+		}
+		for i := range common {
+			if common[i] != segs[i] {
+				common = common[:i]
+				break
+			}
+		}
+	}
+	if len(common) == 0 {
+		return ""
+	}
+	return "/" + strings.Join(common, "/")
+}
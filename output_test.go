@@ -0,0 +1,82 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	schema "github.com/eachain/jsonschema"
+)
+
+const outputSchema = `{
+	"$id": "https://example.com/s",
+	"properties": {
+		"a": {"type": "string", "title": "a string"},
+		"b": {"$ref": "#/definitions/n"}
+	},
+	"definitions": {"n": {"minimum": 1}}
+}`
+
+func TestOutput(t *testing.T) {
+	sc := compile(t, outputSchema, "draft-07")
+	result := sc.Validate(parse(t, `{"a": 1, "b": 0}`))
+	typeErr := `{"valid":false,"keywordLocation":"/properties/a/type",` +
+		`"absoluteKeywordLocation":"https://example.com/s#/properties/a/type",` +
+		`"instanceLocation":"/a","error":"should be one of the allowed types"}`
+	minErr := `{"valid":false,"keywordLocation":"/properties/b/$ref/minimum",` +
+		`"absoluteKeywordLocation":"https://example.com/s#/definitions/n/minimum",` +
+		`"instanceLocation":"/b","error":"should gte 1"}`
+
+	tests := []struct {
+		format schema.OutputFormat
+		want   string
+	}{
+		{schema.FlagOutput, `{"valid":false,"keywordLocation":"","instanceLocation":""}`},
+		{schema.BasicOutput, `{"valid":false,"keywordLocation":"","instanceLocation":"",` +
+			`"errors":[` + typeErr + `,` + minErr + `]}`},
+		// units of a single child are condensed
+		{schema.DetailedOutput, `{"valid":false,"keywordLocation":"","absoluteKeywordLocation":"https://example.com/s#","instanceLocation":"",` +
+			`"errors":[{"valid":false,"keywordLocation":"/properties","absoluteKeywordLocation":"https://example.com/s#/properties","instanceLocation":"",` +
+			`"errors":[` + typeErr + `,` + minErr + `]}]}`},
+		// every level is kept, absolute locations of references are those of their targets
+		{schema.VerboseOutput, `{"valid":false,"keywordLocation":"","absoluteKeywordLocation":"https://example.com/s#","instanceLocation":"",` +
+			`"errors":[{"valid":false,"keywordLocation":"/properties","absoluteKeywordLocation":"https://example.com/s#/properties","instanceLocation":"",` +
+			`"errors":[{"valid":false,"keywordLocation":"/properties/a","absoluteKeywordLocation":"https://example.com/s#/properties/a","instanceLocation":"/a",` +
+			`"errors":[` + typeErr + `]},` +
+			`{"valid":false,"keywordLocation":"/properties/b","instanceLocation":"/b",` +
+			`"errors":[{"valid":false,"keywordLocation":"/properties/b/$ref","absoluteKeywordLocation":"https://example.com/s#/definitions/n","instanceLocation":"/b",` +
+			`"errors":[` + minErr + `]}]}]}]}`},
+	}
+	for _, tt := range tests {
+		got, err := json.Marshal(result.Output(tt.format))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("%v:\ngot  %s\nwant %v", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestOutputValid(t *testing.T) {
+	sc := compile(t, outputSchema, "draft-07")
+
+	var nilResult *schema.Result
+	if out := nilResult.Output(schema.VerboseOutput); !out.Valid || len(out.Errors) > 0 {
+		t.Errorf("nil result: %+v", out)
+	}
+
+	// annotations are reported only if collected
+	result := sc.Validate(parse(t, `{"a": "x"}`))
+	if out := result.Output(schema.BasicOutput); !out.Valid || len(out.Annotations) > 0 {
+		t.Errorf("without annotations: %+v", out)
+	}
+	result = sc.Validate(parse(t, `{"a": "x"}`), schema.WithAnnotations())
+	got, _ := json.Marshal(result.Output(schema.BasicOutput))
+	want := `{"valid":true,"keywordLocation":"","instanceLocation":"",` +
+		`"annotations":[{"valid":true,"keywordLocation":"/properties/a/title",` +
+		`"absoluteKeywordLocation":"https://example.com/s#/properties/a/title",` +
+		`"instanceLocation":"/a","annotation":"a string"}]}`
+	if string(got) != want {
+		t.Errorf("annotations:\ngot  %s\nwant %v", got, want)
+	}
+}
@@ -0,0 +1,630 @@
+package jsonschema
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eachain/jsonschema/jsi"
+)
+
+// ReflectOption sets options of Reflect.
+type ReflectOption func(*reflector)
+
+// WithReflectDraft generates the schema in draft, eg. "draft-07",
+// the latest draft registered by default.
+func WithReflectDraft(draft string) ReflectOption {
+	return func(r *reflector) {
+		r.draft = draft
+	}
+}
+
+// WithTypeMapper maps Go types to schemas before reflecting them,
+// fn returns nil for types reflected as usual. The schema returned is
+// used in place, named types mapped are not put into definitions.
+func WithTypeMapper(fn func(t reflect.Type) jsi.JSON) ReflectOption {
+	return func(r *reflector) {
+		r.mappers = append(r.mappers, fn)
+	}
+}
+
+// Reflect returns the schema of the Go type of v, or of v if it is
+// a reflect.Type, as encoding/json encodes and decodes values of it:
+//
+//   - fields are named by json tags, fields tagged "-" are skipped,
+//     and fields tagged omitempty are not required
+//   - named types other than the one of v are put into definitions
+//     ($defs since draft 2019-09) and referred to by $ref, the type of v
+//     is referred to by "#", so recursive types are fine
+//   - time.Time is a string of format date-time, []byte is a string of
+//     base64, types implementing encoding.TextMarshaler are strings,
+//     other types implementing json.Marshaler are any values
+//   - pointers, slices and maps allow null, which nil is encoded as,
+//     unless they are fields tagged omitempty
+//
+// Constraints of a field are set by tag jsonschema, as comma separated
+// keyword=value, eg. `jsonschema:"minLength=3,pattern=^[a-z]+$"`. Values
+// of enum are separated by "|". A ",", "|" or "\" in a value is escaped
+// by a backslash, eg. `jsonschema:"pattern=^a{1\\,3}$"` where the
+// backslash is doubled in the quoted tag, other backslashes are kept as
+// they are. Flags "required" and "optional" override omitempty. Keywords
+// must be defined in the draft.
+//
+// Channels, functions and complex numbers can't be reflected.
+func Reflect(v any, opts ...ReflectOption) (jsi.JSON, error) {
+	r := &reflector{
+		names:   make(map[reflect.Type]string),
+		named:   make(map[string]bool),
+		defined: make(map[string]jsi.JSON),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.draft == "" {
+		if drafts := supportDrafts(); len(drafts) > 0 {
+			r.draft = drafts[len(drafts)-1]
+		}
+	}
+	if GetKeyword(r.draft, RootKeyword) == nil {
+		return nil, fmt.Errorf("draft %q not registered", r.draft)
+	}
+	r.defsKeyword = "definitions"
+	if r.keyword("$defs") {
+		r.defsKeyword = "$defs"
+	}
+
+	t, ok := v.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(v)
+	}
+	if t == nil {
+		return nil, fmt.Errorf("reflect type of nil")
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	r.root = t
+
+	obj := jsi.NewObject()
+	if uri := metaURIOfDraft(r.draft); uri != "" {
+		set(obj, "$schema", jsi.NewString(uri))
+	}
+	js, err := r.reflectType(t, true)
+	if err != nil {
+		return nil, err
+	}
+	if nilable(t) {
+		js = nullable(js)
+	}
+	merge(obj, js)
+
+	if len(r.order) > 0 {
+		defs := jsi.NewObject()
+		for _, name := range r.order {
+			set(defs, name, r.defined[name])
+		}
+		set(obj, r.defsKeyword, defs)
+	}
+	return obj, nil
+}
+
+type reflector struct {
+	draft       string
+	defsKeyword string
+	mappers     []func(t reflect.Type) jsi.JSON
+
+	root    reflect.Type
+	names   map[reflect.Type]string // definition names of named types
+	named   map[string]bool
+	defined map[string]jsi.JSON
+	order   []string // names in order defined
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	rawMessageType    = reflect.TypeOf(json.RawMessage{})
+	numberType        = reflect.TypeOf(json.Number(""))
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// wellKnown reports whether t is a type of the standard library mapped
+// to a schema in place, instead of being put into definitions.
+func wellKnown(t reflect.Type) bool {
+	return t == timeType || t == rawMessageType || t == numberType
+}
+
+// keyword reports whether keyword is defined in the draft.
+func (r *reflector) keyword(keyword string) bool {
+	return GetKeyword(r.draft, keyword) != nil
+}
+
+// reflectType returns the schema of t, top is true for the type of Reflect.
+func (r *reflector) reflectType(t reflect.Type, top bool) (jsi.JSON, error) {
+	for _, fn := range r.mappers {
+		if js := fn(t); js != nil {
+			return js, nil
+		}
+	}
+	if t.Kind() == reflect.Pointer {
+		return r.reflectType(t.Elem(), false)
+	}
+	if t == r.root && t.Name() != "" && !top {
+		return refSchema("#"), nil
+	}
+	if t.Name() == "" || t.PkgPath() == "" || top || wellKnown(t) {
+		return r.reflectKind(t)
+	}
+
+	name, ok := r.names[t]
+	if !ok {
+		name = r.defName(t)
+		r.names[t] = name
+		js, err := r.reflectKind(t)
+		if err != nil {
+			return nil, err
+		}
+		r.defined[name] = js
+		r.order = append(r.order, name)
+	}
+	return refSchema("#/" + r.defsKeyword + "/" + name), nil
+}
+
+// defName returns an unused name of definitions for named type t.
+func (r *reflector) defName(t reflect.Type) string {
+	clean := func(s string) string {
+		return strings.Map(func(c rune) rune {
+			if c == '_' || c == '-' || c == '.' || '0' <= c && c <= '9' ||
+				'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' {
+				return c
+			}
+			return '_'
+		}, s)
+	}
+	name := clean(t.Name())
+	if r.named[name] {
+		name = clean(path.Base(t.PkgPath()) + "." + t.Name())
+	}
+	unique := name
+	for i := 2; r.named[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	r.named[unique] = true
+	return unique
+}
+
+func (r *reflector) reflectKind(t reflect.Type) (jsi.JSON, error) {
+	switch {
+	case t == timeType:
+		return typeSchema("string", "format", jsi.NewString("date-time")), nil
+	case t == rawMessageType:
+		return jsi.NewObject(), nil
+	case t == numberType:
+		return typeSchema("number"), nil
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return typeSchema("string"), nil
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		return jsi.NewObject(), nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return typeSchema("boolean"), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return typeSchema("integer"), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return typeSchema("integer", "minimum", jsi.NewNumber("0")), nil
+	case reflect.Float32, reflect.Float64:
+		return typeSchema("number"), nil
+	case reflect.String:
+		return typeSchema("string"), nil
+	case reflect.Interface:
+		return jsi.NewObject(), nil
+
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			if r.keyword("contentEncoding") {
+				return typeSchema("string", "contentEncoding", jsi.NewString("base64")), nil
+			}
+			return typeSchema("string"), nil
+		}
+		items, err := r.reflectType(t.Elem(), false)
+		if err != nil {
+			return nil, err
+		}
+		if nilable(t.Elem()) {
+			items = nullable(items)
+		}
+		if t.Kind() == reflect.Array {
+			n := jsi.NewNumber(json.Number(strconv.Itoa(t.Len())))
+			return typeSchema("array", "items", items, "minItems", n, "maxItems", n), nil
+		}
+		return typeSchema("array", "items", items), nil
+
+	case reflect.Map:
+		switch t.Key().Kind() {
+		case reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		default:
+			if !t.Key().Implements(textMarshalerType) {
+				return nil, fmt.Errorf("reflect %v: unsupported map key type", t)
+			}
+		}
+		values, err := r.reflectType(t.Elem(), false)
+		if err != nil {
+			return nil, err
+		}
+		if nilable(t.Elem()) {
+			values = nullable(values)
+		}
+		return typeSchema("object", "additionalProperties", values), nil
+
+	case reflect.Struct:
+		return r.reflectStruct(t)
+	}
+	return nil, fmt.Errorf("reflect %v: unsupported type", t)
+}
+
+// field is a field of a struct as encoding/json sees it.
+type field struct {
+	name      string
+	index     []int
+	typ       reflect.Type
+	omitEmpty bool
+	asString  bool
+	tag       string // tag jsonschema
+}
+
+func (r *reflector) reflectStruct(t reflect.Type) (jsi.JSON, error) {
+	props := jsi.NewObject()
+	var required []jsi.JSON
+	for _, f := range fieldsOf(t) {
+		var js jsi.JSON
+		var err error
+		if f.asString {
+			js = typeSchema("string")
+		} else if js, err = r.reflectType(f.typ, false); err != nil {
+			return nil, err
+		}
+		req := !f.omitEmpty
+		if f.tag != "" {
+			if js, req, err = r.constrain(js, f.tag, req); err != nil {
+				return nil, fmt.Errorf("reflect %v.%v: %w", t, f.name, err)
+			}
+		}
+		if !f.omitEmpty && nilable(f.typ) {
+			js = nullable(js)
+		}
+		set(props, f.name, js)
+		if req {
+			required = append(required, jsi.NewString(f.name))
+		}
+	}
+
+	obj := typeSchema("object")
+	if props.(jsi.Object).Len() > 0 {
+		set(obj, "properties", props)
+	}
+	if len(required) > 0 {
+		set(obj, "required", jsi.NewArray(required...))
+	}
+	return obj, nil
+}
+
+// fieldsOf returns fields of struct t encoded by encoding/json,
+// including those promoted from embedded structs.
+func fieldsOf(t reflect.Type) []field {
+	type level struct {
+		typ   reflect.Type
+		index []int
+	}
+	var fields []field
+	seen := make(map[string]int) // depth of fields by name
+	visited := make(map[reflect.Type]bool)
+	next := []level{{typ: t}}
+	for depth := 0; len(next) > 0; depth++ {
+		current := next
+		next = nil
+		var found []field
+		for _, lv := range current {
+			if visited[lv.typ] {
+				continue
+			}
+			visited[lv.typ] = true
+			for i := 0; i < lv.typ.NumField(); i++ {
+				sf := lv.typ.Field(i)
+				tag := sf.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+				name, opts, _ := strings.Cut(tag, ",")
+				index := append(lv.index[:len(lv.index):len(lv.index)], i)
+				ft := sf.Type
+				if sf.Anonymous && name == "" {
+					if ft.Kind() == reflect.Pointer {
+						ft = ft.Elem()
+					}
+					if ft.Kind() == reflect.Struct {
+						next = append(next, level{typ: ft, index: index})
+						continue
+					}
+				}
+				if !sf.IsExported() {
+					continue
+				}
+				if name == "" {
+					name = sf.Name
+				}
+				f := field{name: name, index: index, typ: sf.Type, tag: sf.Tag.Get("jsonschema")}
+				for _, opt := range strings.Split(opts, ",") {
+					switch opt {
+					case "omitempty":
+						f.omitEmpty = true
+					case "string":
+						switch ft.Kind() {
+						case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+							reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+							reflect.Float32, reflect.Float64, reflect.String:
+							f.asString = true
+						}
+					}
+				}
+				found = append(found, f)
+			}
+		}
+		// fields at a depth hide those deeper, fields of the same name
+		// at the same depth hide each other
+		count := make(map[string]int)
+		for _, f := range found {
+			count[f.name]++
+		}
+		for _, f := range found {
+			if _, ok := seen[f.name]; ok || count[f.name] > 1 {
+				seen[f.name] = depth
+				continue
+			}
+			seen[f.name] = depth
+			fields = append(fields, f)
+		}
+	}
+	// in order of fields declared, as encoding/json encodes them
+	sort.Slice(fields, func(i, j int) bool {
+		a, b := fields[i].index, fields[j].index
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+	return fields
+}
+
+// nilable reports whether encoding/json encodes nil values of t as null.
+// Slices and maps implementing json.Marshaler or encoding.TextMarshaler
+// are encoded by the methods, even if nil.
+func nilable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return true
+	case reflect.Slice, reflect.Map:
+		return !t.Implements(jsonMarshalerType) && !t.Implements(textMarshalerType)
+	}
+	return false
+}
+
+// nullable returns schema js allowing null too.
+func nullable(js jsi.JSON) jsi.JSON {
+	obj, ok := js.(jsi.Object)
+	if !ok || obj.Len() == 0 {
+		return js // any value
+	}
+	typ := obj.Index("type")
+	if typ != nil && typ.Type() == jsi.TypeString && obj.Index("enum") == nil && obj.Index("const") == nil {
+		merged := jsi.NewObject()
+		merge(merged, js)
+		set(merged, "type", jsi.NewArray(jsi.NewString(typ.(jsi.String).Value()), jsi.NewString("null")))
+		return merged
+	}
+	wrapped := jsi.NewObject()
+	set(wrapped, "anyOf", jsi.NewArray(typeSchema("null"), js))
+	return wrapped
+}
+
+// constrain sets keywords of tag jsonschema on js, and returns whether
+// the field is required.
+func (r *reflector) constrain(js jsi.JSON, tag string, required bool) (jsi.JSON, bool, error) {
+	obj := jsi.NewObject()
+	for _, part := range splitTag(tag, ',') {
+		key, val, _ := strings.Cut(part, "=")
+		key = strings.TrimSpace(key)
+		switch key {
+		case "":
+			continue
+		case "required":
+			required = true
+			continue
+		case "optional":
+			required = false
+			continue
+		}
+		if !r.keyword(key) {
+			if key == "const" && r.keyword("enum") {
+				// const is enum of one value before draft-06
+				v, err := tagValue(js, unescapeTag(val))
+				if err != nil {
+					return nil, false, fmt.Errorf("tag jsonschema %v: %w", key, err)
+				}
+				set(obj, "enum", jsi.NewArray(v))
+				continue
+			}
+			return nil, false, fmt.Errorf("tag jsonschema: keyword %v not defined in %v", key, r.draft)
+		}
+
+		var v jsi.JSON
+		var err error
+		if key != "enum" {
+			val = unescapeTag(val)
+		}
+		switch key {
+		case "title", "description", "pattern", "format", "contentEncoding", "contentMediaType", "$comment":
+			v = jsi.NewString(val)
+		case "readOnly", "writeOnly", "deprecated", "uniqueItems":
+			v = jsi.NewBoolean(val == "" || val == "true")
+		case "enum":
+			var items []jsi.JSON
+			for _, s := range splitTag(val, '|') {
+				item, err := tagValue(js, unescapeTag(s))
+				if err != nil {
+					return nil, false, fmt.Errorf("tag jsonschema %v: %w", key, err)
+				}
+				items = append(items, item)
+			}
+			v = jsi.NewArray(items...)
+		case "default", "const":
+			v, err = tagValue(js, val)
+		case "exclusiveMinimum", "exclusiveMaximum":
+			v, err = numberValue(val)
+			if err == nil && r.draft == "draft-04" {
+				// boolean modifier of minimum and maximum in draft-04
+				bound := "minimum"
+				if key == "exclusiveMaximum" {
+					bound = "maximum"
+				}
+				set(obj, bound, v)
+				v = jsi.NewBoolean(true)
+			}
+		default:
+			v, err = numberValue(val)
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("tag jsonschema %v: %w", key, err)
+		}
+		set(obj, key, v)
+	}
+	if obj.(jsi.Object).Len() == 0 {
+		return js, required, nil
+	}
+
+	if js.Type() == jsi.TypeObject && js.(jsi.Object).Index("$ref") != nil && !r.keyword("$defs") {
+		// siblings of $ref are ignored before draft 2019-09
+		wrapped := jsi.NewObject()
+		set(wrapped, "allOf", jsi.NewArray(js))
+		merge(wrapped, obj)
+		return wrapped, required, nil
+	}
+	merged := jsi.NewObject()
+	merge(merged, js)
+	merge(merged, obj)
+	return merged, required, nil
+}
+
+// splitTag splits s by sep not escaped by a backslash,
+// escapes are kept for unescapeTag.
+func splitTag(s string, sep byte) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// unescapeTag removes backslashes escaping ",", "|" or "\\" in s.
+func unescapeTag(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			switch s[i+1] {
+			case ',', '|', '\\':
+				i++
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// tagValue parses s as a value of schema js, a string unless js is
+// of another type.
+func tagValue(js jsi.JSON, s string) (jsi.JSON, error) {
+	var typ string
+	if obj, ok := js.(jsi.Object); ok {
+		if t, ok := obj.Index("type").(jsi.String); ok {
+			typ = t.Value()
+		}
+	}
+	switch typ {
+	case "string":
+		return jsi.NewString(s), nil
+	case "integer", "number":
+		return numberValue(s)
+	case "boolean":
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, err
+		}
+		return jsi.NewBoolean(b), nil
+	}
+	if v, err := jsi.NewBytesParser([]byte(s)).Parse(); err == nil {
+		return v, nil
+	}
+	return jsi.NewString(s), nil
+}
+
+func numberValue(s string) (jsi.JSON, error) {
+	s = strings.TrimSpace(s)
+	if _, err := strconv.ParseFloat(s, 64); err != nil {
+		return nil, fmt.Errorf("%q is not a number", s)
+	}
+	return jsi.NewNumber(json.Number(s)), nil
+}
+
+// typeSchema returns a schema of type typ with keywords and values kvs.
+func typeSchema(typ string, kvs ...any) jsi.JSON {
+	obj := jsi.NewObject()
+	set(obj, "type", jsi.NewString(typ))
+	for i := 0; i+1 < len(kvs); i += 2 {
+		set(obj, kvs[i].(string), kvs[i+1].(jsi.JSON))
+	}
+	return obj
+}
+
+func refSchema(ref string) jsi.JSON {
+	obj := jsi.NewObject()
+	set(obj, "$ref", jsi.NewString(ref))
+	return obj
+}
+
+// set sets member key of object obj made by jsi.NewObject.
+func set(obj jsi.JSON, key string, val jsi.JSON) {
+	obj.(jsi.MutableObject).Set(key, val)
+}
+
+// merge sets members of object js to dst.
+func merge(dst jsi.JSON, js jsi.JSON) {
+	obj, ok := js.(jsi.Object)
+	if !ok {
+		return
+	}
+	iter := obj.Iter()
+	for iter.Next() {
+		key, val := iter.Entry()
+		set(dst, key, val)
+	}
+}
@@ -0,0 +1,183 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	schema "github.com/eachain/jsonschema"
+	"github.com/eachain/jsonschema/jsi"
+)
+
+type address struct {
+	City string `json:"city" jsonschema:"minLength=1"`
+	Zip  string `json:"zip,omitempty" jsonschema:"pattern=^[0-9]{5}$"`
+}
+
+type person struct {
+	Name     string            `json:"name" jsonschema:"minLength=1,maxLength=20"`
+	Age      uint8             `json:"age,omitempty"`
+	Email    *string           `json:"email"`
+	Nick     *string           `json:"nick,omitempty"`
+	Tags     []string          `json:"tags"`
+	Labels   map[string]string `json:"labels"`
+	Avatar   []byte            `json:"avatar"`
+	Home     *address          `json:"home"`
+	Work     address           `json:"work"`
+	Friends  []*person         `json:"friends,omitempty"`
+	Scores   [2]int            `json:"scores"`
+	Role     string            `json:"role" jsonschema:"enum=admin|user"`
+	Extra    any               `json:"extra"`
+	Ignored  int               `json:"-"`
+	internal int
+}
+
+// validate marshals v by encoding/json, and validates it against sc.
+func validate(t *testing.T, sc *schema.Schema, v any) *schema.Result {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sc.Validate(parse(t, string(b)))
+}
+
+func TestReflectValidatesValues(t *testing.T) {
+	email, nick := "a@example.com", "a"
+	values := []any{
+		// nil slices, maps and pointers are null
+		person{Name: "bob", Work: address{City: "x"}, Role: "user"},
+		person{
+			Name: "alice", Age: 30, Email: &email, Nick: &nick,
+			Tags: []string{}, Labels: map[string]string{"a": "b"}, Avatar: []byte("x"),
+			Home: &address{City: "x", Zip: "12345"}, Work: address{City: "y"},
+			Friends: []*person{{Name: "bob", Work: address{City: "x"}, Role: "user"}, nil}, Role: "admin", Extra: []int{1},
+		},
+	}
+	for _, draft := range []string{"draft-04", "draft-06", "draft-07", "draft-201909", "draft-202012"} {
+		js, err := schema.Reflect(person{}, schema.WithReflectDraft(draft))
+		if err != nil {
+			t.Fatalf("%v: %v", draft, err)
+		}
+		sc, result := schema.Compile(js)
+		if !result.Valid() {
+			t.Fatalf("%v: compile %v: %v", draft, js, result.Error())
+		}
+		if sc.Draft() != draft {
+			t.Errorf("%v: compiled as %v", draft, sc.Draft())
+		}
+		for _, v := range values {
+			if result := validate(t, sc, v); !result.Valid() {
+				t.Errorf("%v: %+v: %v", draft, v, result.Error())
+			}
+		}
+
+		// omitempty pointers are never null, constraints are kept
+		for _, s := range []string{
+			`{"nick": null}`,
+			`{"name": "", "email": null}`,
+			`{"home": {"city": ""}}`,
+			`{"role": "guest"}`,
+			`{"scores": [1]}`,
+		} {
+			doc := parse(t, s)
+			if sc.Validate(doc).Valid() {
+				t.Errorf("%v: %v validated", draft, s)
+			}
+		}
+	}
+}
+
+func TestReflectNullable(t *testing.T) {
+	js, err := schema.Reflect(person{}, schema.WithReflectDraft("draft-07"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	props := js.(jsi.Object).Index("properties").(jsi.Object)
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"email", `{"type":["string","null"]}`},
+		{"nick", `{"type":"string"}`},
+		{"tags", `{"type":["array","null"],"items":{"type":"string"}}`},
+		{"labels", `{"type":["object","null"],"additionalProperties":{"type":"string"}}`},
+		{"avatar", `{"type":["string","null"],"contentEncoding":"base64"}`},
+		{"home", `{"anyOf":[{"type":"null"},{"$ref":"#/definitions/address"}]}`},
+		{"work", `{"$ref":"#/definitions/address"}`},
+		{"friends", `{"type":"array","items":{"anyOf":[{"type":"null"},{"$ref":"#"}]}}`},
+		{"scores", `{"type":"array","items":{"type":"integer"},"minItems":2,"maxItems":2}`},
+		{"extra", `{}`},
+	}
+	for _, tt := range tests {
+		got, _ := json.Marshal(props.Index(tt.name))
+		if string(got) != tt.want {
+			t.Errorf("%v: got %s, want %v", tt.name, got, tt.want)
+		}
+	}
+	if props.Index("Ignored") != nil || props.Index("internal") != nil {
+		t.Errorf("skipped fields reflected")
+	}
+	required, _ := json.Marshal(js.(jsi.Object).Index("required"))
+	if want := `["name","email","tags","labels","avatar","home","work","scores","role","extra"]`; string(required) != want {
+		t.Errorf("required: got %s, want %v", required, want)
+	}
+
+	// slices of the top are nullable too
+	js, err = schema.Reflect([]int(nil), schema.WithReflectDraft("draft-07"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := json.Marshal(js.(jsi.Object).Index("type")); string(got) != `["array","null"]` {
+		t.Errorf("type of a slice: got %s", got)
+	}
+}
+
+func TestReflectTagEscapes(t *testing.T) {
+	type tagged struct {
+		Code  string `json:"code" jsonschema:"pattern=^a{1\\,3}$,title=a\\|b\\\\c"`
+		Sep   string `json:"sep" jsonschema:"enum=a\\|b|c\\,d"`
+		Digit string `json:"digit" jsonschema:"pattern=^\\d+$"`
+	}
+	js, err := schema.Reflect(tagged{}, schema.WithReflectDraft("draft-07"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	props := js.(jsi.Object).Index("properties").(jsi.Object)
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"code", `{"type":"string","pattern":"^a{1,3}$","title":"a|b\\c"}`},
+		{"sep", `{"type":"string","enum":["a|b","c,d"]}`},
+		{"digit", `{"type":"string","pattern":"^\\d+$"}`},
+	}
+	for _, tt := range tests {
+		got, _ := json.Marshal(props.Index(tt.name))
+		if string(got) != tt.want {
+			t.Errorf("%v: got %s, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestReflectTagErrors(t *testing.T) {
+	tests := []any{
+		struct {
+			A string `jsonschema:"pattern=^a{1,3}$"` // comma not escaped
+		}{},
+		struct {
+			A string `jsonschema:"unknown=1"`
+		}{},
+		struct {
+			A int `jsonschema:"minimum=x"`
+		}{},
+	}
+	for _, v := range tests {
+		if js, err := schema.Reflect(v, schema.WithReflectDraft("draft-07")); err == nil {
+			t.Errorf("%v reflected: %v", reflect.TypeOf(v), js)
+		} else if !strings.Contains(err.Error(), "tag jsonschema") {
+			t.Errorf("%v: %v", reflect.TypeOf(v), err)
+		}
+	}
+}
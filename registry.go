@@ -0,0 +1,153 @@
+package jsonschema
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/eachain/jsonschema/jsi"
+)
+
+// Registry compiles schemas sharing schema resources: resources added
+// by AddResource are resolved first before loading by Loader, and
+// resources compiled once are reused by later compiling.
+//
+// It is named Registry since Compiler is the compiler of keywords.
+type Registry struct {
+	Loader Loader // DefaultLoader if nil
+
+	// ValidateSchema validates schemas against the meta-schema
+	// of their drafts while compiling them.
+	ValidateSchema bool
+
+	mu        sync.Mutex
+	resources map[string]jsi.JSON
+	compiled  map[string]map[string]sharedResource
+}
+
+// NewRegistry returns an empty Registry loading by DefaultLoader.
+func NewRegistry() *Registry {
+	return &Registry{
+		resources: make(map[string]jsi.JSON),
+		compiled:  make(map[string]map[string]sharedResource),
+	}
+}
+
+// AddResource registers schema document js by uri, or by its $id
+// (id for draft-04) if uri is empty.
+func (r *Registry) AddResource(uri string, js jsi.JSON) error {
+	if uri == "" {
+		uri = idOf(js)
+		if uri == "" {
+			return fmt.Errorf("schema resource without uri or $id")
+		}
+	}
+	ptr, err := ParsePointer(uri)
+	if err != nil {
+		return err
+	}
+	ptr.Frag = nil
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.init()
+	r.resources[strings.TrimSuffix(ptr.String(), "#")] = js
+	return nil
+}
+
+func idOf(js jsi.JSON) string {
+	if js.Type() != jsi.TypeObject {
+		return ""
+	}
+	for _, kw := range []string{"$id", "id"} {
+		if id := js.(jsi.Object).Index(kw); id != nil && id.Type() == jsi.TypeString {
+			return id.(jsi.String).Value()
+		}
+	}
+	return ""
+}
+
+func (r *Registry) init() {
+	if r.resources == nil {
+		r.resources = make(map[string]jsi.JSON)
+	}
+	if r.compiled == nil {
+		r.compiled = make(map[string]map[string]sharedResource)
+	}
+}
+
+// Compile compiles js as Compile, with resources of r.
+// js is also added as a resource if it has an absolute $id.
+func (r *Registry) Compile(js jsi.JSON, drafts ...string) (*Schema, *Result) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.init()
+
+	if ptr, err := ParsePointer(idOf(js)); err == nil && ptr.Scheme != "" {
+		ptr.Frag = nil
+		r.resources[strings.TrimSuffix(ptr.String(), "#")] = js
+	}
+
+	env := &compileEnv{
+		loader:   LoaderFunc(r.load),
+		validate: r.ValidateSchema,
+		shared:   r.compiled,
+	}
+	return env.compile(js, drafts...)
+}
+
+// CompileURI compiles the schema document at uri, which is either
+// added by AddResource or loaded by Loader.
+func (r *Registry) CompileURI(uri string, drafts ...string) (*Schema, *Result) {
+	base, err := ParsePointer(uri)
+	if err != nil {
+		return nil, WithError(Error{
+			Field: uri,
+			Type:  TypeString,
+			Value: uri,
+			Msg:   "should be uri",
+		})
+	}
+	base.Frag = nil
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.init()
+
+	js, err := r.load(strings.TrimSuffix(base.String(), "#"))
+	if err != nil {
+		return nil, WithError(Error{
+			Field: uri,
+			Type:  TypeString,
+			Value: uri,
+			Msg:   "load schema from uri: " + err.Error(),
+		})
+	}
+
+	env := &compileEnv{
+		loader:   LoaderFunc(r.load),
+		base:     base,
+		validate: r.ValidateSchema,
+		shared:   r.compiled,
+	}
+	return env.compile(js, drafts...)
+}
+
+// load is called with r.mu locked.
+func (r *Registry) load(uri string) (jsi.JSON, error) {
+	uri = strings.TrimSuffix(uri, "#")
+	if js := r.resources[uri]; js != nil {
+		return js, nil
+	}
+
+	loader := r.Loader
+	if loader == nil {
+		loader = DefaultLoader
+	}
+	js, err := loader.Load(uri)
+	if err != nil {
+		return nil, err
+	}
+	r.resources[uri] = js
+	return js, nil
+}
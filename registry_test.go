@@ -0,0 +1,109 @@
+package jsonschema_test
+
+import (
+	"strings"
+	"testing"
+
+	schema "github.com/eachain/jsonschema"
+	"github.com/eachain/jsonschema/jsi"
+)
+
+func TestRegistry(t *testing.T) {
+	loads := make(map[string]int)
+	reg := schema.NewRegistry()
+	reg.Loader = schema.LoaderFunc(func(uri string) (jsi.JSON, error) {
+		loads[uri]++
+		return schema.MemoryLoader{
+			"https://example.com/name.json": []byte(`{"type": "string", "minLength": 1}`),
+		}.Load(uri)
+	})
+	if err := reg.AddResource("https://example.com/age.json", parse(t, `{"type": "integer", "minimum": 0}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := reg.AddResource("", parse(t, `{"$id": "https://example.com/tags.json#", "type": "array"}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	s := `{
+		"$id": "https://example.com/person.json",
+		"properties": {
+			"name": {"$ref": "name.json"},
+			"age": {"$ref": "age.json"},
+			"tags": {"$ref": "tags.json"}
+		}
+	}`
+	// the draft is given, draft-04 tried otherwise doesn't know $id
+	for i := 0; i < 2; i++ {
+		sc, result := reg.Compile(parse(t, s), "draft-07")
+		if !result.Valid() {
+			t.Fatalf("compile: %v", result.Error())
+		}
+		if !sc.Validate(parse(t, `{"name": "a", "age": 1, "tags": []}`)).Valid() {
+			t.Errorf("valid instance failed")
+		}
+		for _, doc := range []string{`{"name": ""}`, `{"age": -1}`, `{"tags": {}}`} {
+			if sc.Validate(parse(t, doc)).Valid() {
+				t.Errorf("%v validated", doc)
+			}
+		}
+	}
+	// resources added are never loaded, loaded ones are loaded once
+	want := map[string]int{"https://example.com/name.json": 1}
+	if len(loads) != len(want) || loads["https://example.com/name.json"] != 1 {
+		t.Errorf("loads: got %v, want %v", loads, want)
+	}
+
+	// schemas compiled with absolute $id are resources
+	sc, result := reg.CompileURI("https://example.com/person.json#", "draft-07")
+	if !result.Valid() {
+		t.Fatalf("compile uri: %v", result.Error())
+	}
+	if sc.Validate(parse(t, `{"age": "1"}`)).Valid() {
+		t.Errorf("schema compiled by uri validated invalid instance")
+	}
+}
+
+func TestRegistryErrors(t *testing.T) {
+	var reg schema.Registry // the zero value is ready to use
+	reg.Loader = schema.NoLoader
+
+	if err := reg.AddResource("", parse(t, `{"type": "string"}`)); err == nil {
+		t.Errorf("resource without uri added")
+	}
+	if err := reg.AddResource("://", parse(t, `{}`)); err == nil {
+		t.Errorf("resource of invalid uri added")
+	}
+
+	if _, result := reg.CompileURI("https://example.com/none.json"); result.Valid() ||
+		!strings.Contains(result.Error(), "load schema from uri") {
+		t.Errorf("compile unknown uri: %v", result.Error())
+	}
+	if _, result := reg.Compile(parse(t, `{"$ref": "https://example.com/none.json"}`), "draft-07"); result.Valid() {
+		t.Errorf("unresolved reference compiled")
+	}
+}
+
+func TestRegistryUnevaluated(t *testing.T) {
+	a := `{
+		"$id": "https://example.com/a.json",
+		"allOf": [{"properties": {"x": {}}}],
+		"unevaluatedProperties": false
+	}`
+	b := `{"$ref": "https://example.com/a.json"}`
+
+	// b reuses a compiled before, which tracks evaluated members
+	reg := schema.NewRegistry()
+	reg.Loader = schema.NoLoader
+	for _, s := range []string{a, b} {
+		sc, result := reg.Compile(parse(t, s), "draft-201909")
+		if !result.Valid() {
+			t.Fatalf("compile %v: %v", s, result.Error())
+		}
+		if result := sc.Validate(parse(t, `{"x": 1}`)); !result.Valid() {
+			t.Errorf("%v: %v", s, result.Error())
+		}
+		if sc.Validate(parse(t, `{"y": 1}`)).Valid() {
+			t.Errorf("%v: unevaluated property validated", s)
+		}
+	}
+}
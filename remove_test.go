@@ -0,0 +1,67 @@
+package jsonschema_test
+
+import (
+	"testing"
+
+	schema "github.com/eachain/jsonschema"
+)
+
+func TestRemoveAdditional(t *testing.T) {
+	const (
+		declared = `{"properties": {"a": true}, "patternProperties": {"^x": true}}`
+		closed   = `{"properties": {"a": true}, "additionalProperties": false}`
+		typed    = `{"properties": {"a": true}, "additionalProperties": {"type": "integer"}}`
+		nested   = `{"properties": {"o": {"properties": {"a": true}, "additionalProperties": false}}}`
+	)
+	tests := []struct {
+		mode             schema.RemoveMode
+		schema, instance string
+		want             string
+		valid            bool
+	}{
+		{schema.RemoveAll, declared, `{"a": 1, "x1": 2, "b": 3}`, `{"a":1,"x1":2}`, true},
+		{schema.RemoveAll, closed, `{"a": 1, "b": 3}`, `{"a":1}`, true},
+		{schema.RemoveAll, typed, `{"a": 1, "b": 3, "c": "x"}`, `{"a":1}`, true},
+		{schema.RemoveAll, nested, `{"o": {"a": 1, "b": 3}}`, `{"o":{"a":1}}`, true},
+		{schema.RemoveAll, `{"type": "object"}`, `{"b": 3}`, `{"b":3}`, true},
+
+		{schema.RemoveFailing, declared, `{"a": 1, "b": 3}`, `{"a":1,"b":3}`, true},
+		{schema.RemoveFailing, closed, `{"a": 1, "b": 3}`, `{"a":1}`, true},
+		{schema.RemoveFailing, typed, `{"a": 1, "b": 3, "c": "x"}`, `{"a":1,"b":3}`, true},
+		{schema.RemoveFailing, nested, `{"o": {"a": 1, "b": 3}}`, `{"o":{"a":1}}`, true},
+
+		{schema.RemoveDeclaredFalse, declared, `{"a": 1, "b": 3}`, `{"a":1,"b":3}`, true},
+		{schema.RemoveDeclaredFalse, closed, `{"a": 1, "b": 3}`, `{"a":1}`, true},
+		{schema.RemoveDeclaredFalse, typed, `{"a": 1, "c": "x"}`, `{"a":1,"c":"x"}`, false},
+		{schema.RemoveDeclaredFalse, nested, `{"o": {"a": 1, "b": 3}}`, `{"o":{"a":1}}`, true},
+	}
+	for _, tt := range tests {
+		got, result := apply(t, tt.schema, tt.instance, schema.WithRemoveAdditional(tt.mode))
+		if result.Valid() != tt.valid || got != tt.want {
+			t.Errorf("%v: %v with %v: got %v valid %v, want %v valid %v: %v", tt.mode,
+				tt.schema, tt.instance, got, result.Valid(), tt.want, tt.valid, result.Error())
+		}
+	}
+}
+
+func TestRemoveAdditionalSubschemas(t *testing.T) {
+	// each subschema of allOf removes members it doesn't declare
+	allOf := `{"allOf": [
+		{"properties": {"a": true}, "additionalProperties": false},
+		{"properties": {"b": true}}
+	]}`
+	if got, result := apply(t, allOf, `{"a": 1, "b": 2}`, schema.WithRemoveAdditional(schema.RemoveFailing)); !result.Valid() || got != `{"a":1}` {
+		t.Errorf("allOf: got %v, %v", got, result.Error())
+	}
+
+	// nothing is removed where failing doesn't fail the instance
+	anyOf := `{"anyOf": [{"properties": {"a": true}, "additionalProperties": false}]}`
+	if got, result := apply(t, anyOf, `{"a": 1, "b": 2}`, schema.WithRemoveAdditional(schema.RemoveAll)); result.Valid() || got != `{"a":1,"b":2}` {
+		t.Errorf("anyOf: got %v, %v", got, result.Error())
+	}
+
+	// only with the option
+	if got, result := apply(t, `{"additionalProperties": false}`, `{"a": 1}`); result.Valid() || got != `{"a":1}` {
+		t.Errorf("without option: got %v, %v", got, result.Error())
+	}
+}
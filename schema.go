@@ -1,26 +1,139 @@
 package jsonschema
 
 import (
+	"context"
+	"strings"
+
 	"github.com/eachain/jsonschema/jsi"
 )
 
 type Schema struct {
-	draft string
-	val   Validator
+	draft      string
+	val        Validator
+	evaluating bool
+	refs       map[jsi.JSON]jsi.JSON // schemas resolved to, by references
 }
 
 func (s *Schema) Draft() string {
 	return s.draft
 }
 
-func (s *Schema) Validate(js jsi.JSON) *Result {
+// Resolve returns the schema which reference ref resolves to, ref is the
+// value of $ref, $recursiveRef or $dynamicRef in the schema compiled or in
+// documents loaded for it. $recursiveRef and $dynamicRef resolve to the
+// schema they refer to without dynamic scope. It returns nil if ref is
+// not a reference compiled, or it refers to a resource compiled before
+// by a Registry.
+func (s *Schema) Resolve(ref jsi.JSON) jsi.JSON {
+	return s.refs[ref]
+}
+
+// Validate validates js, which is never changed, options changing
+// the instance, eg. WithDefaults, take effect only in Apply.
+func (s *Schema) Validate(js jsi.JSON, opts ...ValidateOption) *Result {
+	_, result := s.validate(js, readOnlyOptions(opts))
+	return result
+}
+
+// ValidateContext validates js as Validate, it stops as soon as c is done,
+// and returns c.Err() with the result, which has an error of CodeCanceled.
+func (s *Schema) ValidateContext(c context.Context, js jsi.JSON, opts ...ValidateOption) (*Result, error) {
+	o := readOnlyOptions(opts)
+	o.context = c
+	_, result := s.validate(js, o)
+	return result, c.Err()
+}
+
+// readOnlyOptions drops options changing the instance.
+func readOnlyOptions(opts []ValidateOption) *validateOptions {
+	o := newValidateOptions(opts)
+	o.defaults = false
+	o.coercing = false
+	o.removing = ""
+	return o
+}
+
+// Apply validates a copy of js changed by options like WithDefaults,
+// and returns the copy, leaving js as it is.
+func (s *Schema) Apply(js jsi.JSON, opts ...ValidateOption) (jsi.JSON, *Result) {
+	return s.validate(jsi.Clone(js), newValidateOptions(opts))
+}
+
+// validate returns js, or the value replacing it, with the result.
+func (s *Schema) validate(js jsi.JSON, o *validateOptions) (jsi.JSON, *Result) {
 	if s.val == nil {
-		return nil
+		return js, nil
+	}
+
+	ctx := newContext(s.draft)
+	*ctx.evaluating = s.evaluating
+	ctx.annotating = o.annotating
+	ctx.defaults = o.defaults
+	ctx.coercing = o.coercing
+	ctx.removing = o.removing
+	ctx.cancel = o.context
+	ctx.maxErrors = o.maxErrors
+	if o.coercing {
+		ctx.replaced = make(map[jsi.JSON]jsi.JSON)
 	}
-	return s.val.Validate(newContext(s.draft), js)
+	result := s.val.Validate(ctx, js)
+	js = ctx.Current(js)
+	if result != nil {
+		result.evaluated = nil
+		if o.maxErrors > 0 && len(result.Errors) > o.maxErrors {
+			result.Errors = result.Errors[:o.maxErrors]
+		}
+		if !result.Valid() {
+			result.Annotations = nil
+			if o.catalog != nil {
+				result.Localize(o.catalog)
+			}
+		}
+		if len(result.Errors) == 0 && len(result.Warnings) == 0 && len(result.Annotations) == 0 &&
+			len(result.Coercions) == 0 {
+			return js, nil
+		}
+	}
+	return js, result
 }
 
 func Compile(js jsi.JSON, drafts ...string) (*Schema, *Result) {
+	return CompileWithLoader(DefaultLoader, js, drafts...)
+}
+
+// CompileWithLoader compiles js as Compile, references which can't be
+// resolved in js are loaded by loader.
+func CompileWithLoader(loader Loader, js jsi.JSON, drafts ...string) (*Schema, *Result) {
+	env := &compileEnv{loader: loader}
+	return env.compile(js, drafts...)
+}
+
+// compileEnv is the environment shared by compiling js with each draft.
+type compileEnv struct {
+	loader   Loader
+	base     *Pointer // base uri of js, nil if none
+	validate bool     // validate js against the meta-schema of draft
+
+	// schema resources compiled before by draft, shared by Registry
+	shared map[string]map[string]sharedResource
+}
+
+// sharedResource is a schema resource compiled before, evaluating is that
+// of the schema compiled with it, which its validators are built for.
+type sharedResource struct {
+	val        Validator
+	evaluating bool
+}
+
+type compiled struct {
+	val        Validator
+	evaluating bool
+	impl       map[string]Validator
+	refs       map[jsi.JSON]jsi.JSON
+	result     *Result
+}
+
+func (env *compileEnv) compile(js jsi.JSON, drafts ...string) (*Schema, *Result) {
 	if len(drafts) == 0 {
 		drafts = supportDrafts()
 	}
@@ -33,48 +146,123 @@ func Compile(js jsi.JSON, drafts ...string) (*Schema, *Result) {
 		}}}
 	}
 
-	draft := drafts[0]
-	val, result := compile(drafts[0], js)
-	warns := 0
-	if result != nil {
-		warns = len(result.Warnings)
-	}
-	if result.Valid() && warns == 0 {
-		return &Schema{draft: draft, val: val}, nil
+	// a draft declared by $schema is the only one js is compiled as,
+	// others are tried only if $schema is absent or unknown
+	if declared := declaredDraft(js); declared != "" {
+		for _, d := range drafts {
+			if d == declared {
+				drafts = []string{declared}
+				break
+			}
+		}
 	}
 
-	for i := 1; i < len(drafts); i++ {
-		v, r := compile(drafts[i], js)
-		if !r.Valid() {
+	draft := drafts[0]
+	best := env.compileDraft(drafts[0], js)
+	for i := 1; i < len(drafts) && !(best.result.Valid() && best.warns() == 0); i++ {
+		c := env.compileDraft(drafts[i], js)
+		if !c.result.Valid() {
 			continue
 		}
-		ws := 0
-		if r != nil {
-			ws = len(r.Warnings)
+		if !best.result.Valid() || c.better(best) {
+			draft = drafts[i]
+			best = c
 		}
+	}
 
-		if !result.Valid() || ws < warns {
-			draft = drafts[i]
-			val = v
-			result = r
+	if best.result.Valid() && env.validate {
+		if r := ValidateSchema(js, draft); !r.Valid() {
+			best.result = best.result.Merge(&Result{Errors: r.Errors})
 		}
-		if ws == 0 {
-			break
+	}
+
+	if best.result.Valid() {
+		env.share(draft, best.impl, best.evaluating)
+		if best.warns() == 0 {
+			best.result = nil
 		}
 	}
-	return &Schema{draft: draft, val: val}, result
+	return &Schema{draft: draft, val: best.val, evaluating: best.evaluating, refs: best.refs}, best.result
 }
 
-func compile(draft string, js jsi.JSON) (Validator, *Result) {
+// declaredDraft returns the registered draft which $schema of js
+// refers to, or "" if none.
+func declaredDraft(js jsi.JSON) string {
+	obj, ok := js.(jsi.Object)
+	if !ok {
+		return ""
+	}
+	uri, ok := obj.Index("$schema").(jsi.String)
+	if !ok {
+		return ""
+	}
+	return draftOfMetaURI(uri.Value())
+}
+
+func (c compiled) warns() int {
+	if c.result == nil {
+		return 0
+	}
+	return len(c.result.Warnings)
+}
+
+// better prefers the draft with fewer warnings: referenced resources
+// shared by Registry are compiled without warnings.
+func (c compiled) better(than compiled) bool {
+	return c.warns() < than.warns()
+}
+
+func (env *compileEnv) compileDraft(draft string, js jsi.JSON) compiled {
 	root := GetKeyword(draft, RootKeyword)
 	if root == nil {
-		return nil, &Result{Errors: []Error{{
+		return compiled{result: &Result{Errors: []Error{{
 			Field: ".",
 			Type:  js.Type(),
 			Value: js,
 			Msg:   MsgRootCompilerNotFound,
-		}}}
+		}}}}
 	}
 	ctx := newContext(draft)
-	return root.Compile(ctx, js)
+	ctx.loader = env.loader
+	for uri, res := range env.shared[draft] {
+		ctx.impl[uri] = res.val
+	}
+	if env.base != nil {
+		ctx = ctx.WithId(env.base)
+		ctx.loaded[strings.TrimSuffix(env.base.String(), "#")] = true
+	}
+	val, result := root.Compile(ctx, js)
+	refs := ctx.resolved()
+
+	// unevaluated* of shared resources referred to need tracking too
+	evaluating := ctx.Evaluating()
+	for uri := range ctx.ref {
+		if ctx.nodes[uri] == nil && env.shared[draft][uri].evaluating {
+			evaluating = true
+		}
+	}
+	return compiled{
+		val:        val,
+		evaluating: evaluating,
+		impl:       ctx.impl,
+		refs:       refs,
+		result:     result,
+	}
+}
+
+// share keeps compiled schema resources with absolute uri for later compiling.
+func (env *compileEnv) share(draft string, impl map[string]Validator, evaluating bool) {
+	if env.shared == nil {
+		return
+	}
+	shared := env.shared[draft]
+	if shared == nil {
+		shared = make(map[string]sharedResource)
+		env.shared[draft] = shared
+	}
+	for uri, val := range impl {
+		if !strings.HasPrefix(uri, "#") {
+			shared[uri] = sharedResource{val: val, evaluating: evaluating || shared[uri].evaluating}
+		}
+	}
 }
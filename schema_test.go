@@ -0,0 +1,120 @@
+package jsonschema_test
+
+import (
+	"strings"
+	"testing"
+
+	schema "github.com/eachain/jsonschema"
+	"github.com/eachain/jsonschema/jsi"
+)
+
+func TestCompileDeclaredDraft(t *testing.T) {
+	tests := []struct {
+		schema string
+		draft  string
+	}{
+		{`{"$schema": "http://json-schema.org/draft-04/schema#", "minimum": 1, "exclusiveMinimum": true}`, "draft-04"},
+		{`{"$schema": "http://json-schema.org/draft-06/schema", "const": 1}`, "draft-06"},
+		{`{"$schema": "http://json-schema.org/draft-07/schema#", "if": {"type": "string"}}`, "draft-07"},
+		{`{"$schema": "https://json-schema.org/draft/2019-09/schema", "$defs": {"a": {}}}`, "draft-201909"},
+		{`{"$schema": "https://json-schema.org/draft/2020-12/schema#", "prefixItems": [true]}`, "draft-202012"},
+	}
+	for _, tt := range tests {
+		sc := compile(t, tt.schema)
+		if sc.Draft() != tt.draft {
+			t.Errorf("%v: compiled as %v, want %v", tt.schema, sc.Draft(), tt.draft)
+		}
+	}
+}
+
+func TestCompileDeclaredDraftErrors(t *testing.T) {
+	tests := []struct {
+		schema string
+		msg    string
+	}{
+		// a keyword unknown in other drafts doesn't make them compile it
+		{`{"$schema": "http://json-schema.org/draft-07/schema#", "if": {"minLength": "x"}}`, "should be"},
+		// errors are those of the declared draft
+		{`{"$schema": "https://json-schema.org/draft/2019-09/schema", "$ref": "#/$defs/missing"}`, "$defs/missing"},
+	}
+	for _, tt := range tests {
+		_, result := schema.Compile(parse(t, tt.schema))
+		if result.Valid() {
+			t.Errorf("%v: compiled", tt.schema)
+			continue
+		}
+		if !strings.Contains(result.Error(), tt.msg) {
+			t.Errorf("%v: got %v, want error with %q", tt.schema, result.Error(), tt.msg)
+		}
+	}
+}
+
+func TestCompileUndeclaredDraft(t *testing.T) {
+	// without $schema, the draft fitting best is chosen
+	if sc := compile(t, `{"minimum": 1, "exclusiveMinimum": true}`); sc.Draft() != "draft-04" {
+		t.Errorf("boolean exclusiveMinimum compiled as %v", sc.Draft())
+	}
+	if sc := compile(t, `{"type": "array", "prefixItems": [true]}`); sc.Draft() != "draft-202012" {
+		t.Errorf("prefixItems compiled as %v", sc.Draft())
+	}
+
+	// drafts given explicitly win over $schema
+	sc, _ := schema.Compile(parse(t, `{"$schema": "http://json-schema.org/draft-07/schema#"}`), "draft-06")
+	if sc.Draft() != "draft-06" {
+		t.Errorf("compiled as %v, want draft-06", sc.Draft())
+	}
+}
+
+func TestValidateSchemaDeclaredDraft(t *testing.T) {
+	// compiling meta-schemas looks up drafts of $schema too
+	for _, s := range []string{
+		`{"$schema": "http://json-schema.org/draft-07/schema#", "type": "object"}`,
+		`{"$schema": "https://json-schema.org/draft/2020-12/schema", "type": "object"}`,
+	} {
+		if result := schema.ValidateSchema(parse(t, s), "draft-07"); !result.Valid() {
+			t.Errorf("%v: %v", s, result.Error())
+		}
+	}
+	if result := schema.ValidateSchema(parse(t, `{"type": 1}`), "draft-07"); result.Valid() {
+		t.Errorf("invalid schema validated")
+	}
+
+	reg := schema.NewRegistry()
+	reg.ValidateSchema = true
+	if _, result := reg.Compile(parse(t, `{"$schema": "http://json-schema.org/draft-06/schema#", "minLength": -1}`)); result.Valid() {
+		t.Errorf("schema invalid against its meta-schema compiled")
+	}
+}
+
+func TestResolve(t *testing.T) {
+	js := parse(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$dynamicAnchor": "node",
+		"properties": {
+			"a": {"$ref": "#/$defs/a"},
+			"b": {"$ref": "#b"},
+			"next": {"$dynamicRef": "#node"}
+		},
+		"$defs": {"a": {"type": "integer"}, "b": {"$anchor": "b", "type": "string"}}
+	}`)
+	sc, result := schema.Compile(js)
+	if !result.Valid() {
+		t.Fatal(result.Error())
+	}
+	props := js.(jsi.Object).Index("properties").(jsi.Object)
+	defs := js.(jsi.Object).Index("$defs").(jsi.Object)
+	tests := []struct {
+		ref  jsi.JSON
+		want jsi.JSON
+	}{
+		{props.Index("a").(jsi.Object).Index("$ref"), defs.Index("a")},
+		{props.Index("b").(jsi.Object).Index("$ref"), defs.Index("b")},
+		{props.Index("next").(jsi.Object).Index("$dynamicRef"), js},
+		{props.Index("a"), nil},
+	}
+	for i, tt := range tests {
+		if got := sc.Resolve(tt.ref); got != tt.want {
+			t.Errorf("%d: %v resolved to %v, want %v", i, tt.ref, got, tt.want)
+		}
+	}
+}
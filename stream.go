@@ -0,0 +1,52 @@
+package jsonschema
+
+import (
+	"io"
+
+	"github.com/eachain/jsonschema/jsi"
+)
+
+// StreamResult is the result of a value validated by ValidateStream.
+type StreamResult struct {
+	Index int          // index of the value from 0, eg. element index of an array
+	Pos   jsi.Position // where the value starts, Pos.Line is the line of NDJSON
+	Value jsi.JSON
+	*Result
+}
+
+// ValidateStream validates values parsed by p one by one until io.EOF,
+// and calls fn with the result of each, valid or not. Values are
+// released after fn returns, so memory is bounded by a single value
+// if p parses incrementally, eg. jsi.NewArrayParser or jsi.NewNDJSONParser.
+// It stops at the first error returned by p or fn.
+func (s *Schema) ValidateStream(p jsi.Parser, fn func(StreamResult) error, opts ...ValidateOption) error {
+	for i := 0; ; i++ {
+		js, err := p.Parse()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		err = fn(StreamResult{
+			Index:  i,
+			Pos:    jsi.PositionOf(js),
+			Value:  js,
+			Result: s.Validate(js, opts...),
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// ValidateArray validates elements of a top-level JSON array read from r.
+func (s *Schema) ValidateArray(r io.Reader, fn func(StreamResult) error, opts ...ValidateOption) error {
+	return s.ValidateStream(jsi.NewArrayParser(r), fn, opts...)
+}
+
+// ValidateNDJSON validates lines of newline-delimited JSON read from r.
+func (s *Schema) ValidateNDJSON(r io.Reader, fn func(StreamResult) error, opts ...ValidateOption) error {
+	return s.ValidateStream(jsi.NewNDJSONParser(r), fn, opts...)
+}
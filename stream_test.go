@@ -0,0 +1,83 @@
+package jsonschema_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	schema "github.com/eachain/jsonschema"
+	"github.com/eachain/jsonschema/jsi"
+)
+
+// collect returns "index@line:col valid" of each result.
+func collect(results *[]string) func(schema.StreamResult) error {
+	return func(r schema.StreamResult) error {
+		*results = append(*results, fmt.Sprintf("%d@%v %v", r.Index, r.Pos, r.Valid()))
+		return nil
+	}
+}
+
+func TestValidateArray(t *testing.T) {
+	sc := compile(t, `{"type": "integer", "minimum": 0}`, "draft-07")
+	var results []string
+	err := sc.ValidateArray(strings.NewReader("[1,\n -1, \"a\",\n 2]"), collect(&results))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "0@1:2 true, 1@2:2 false, 2@2:6 false, 3@3:2 true"
+	if got := strings.Join(results, ", "); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	results = nil
+	err = sc.ValidateArray(strings.NewReader(`[1, 2}`), collect(&results))
+	var se *jsi.SyntaxError
+	if !errors.As(err, &se) || len(results) != 2 {
+		t.Errorf("got %v, %v, want syntax error after 2 results", results, err)
+	}
+}
+
+func TestValidateNDJSON(t *testing.T) {
+	sc := compile(t, `{"required": ["id"]}`, "draft-07")
+	var results []string
+	err := sc.ValidateNDJSON(strings.NewReader("{\"id\": 1}\n\n{}\n{\"id\": 2}\n"), collect(&results))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "0@1:1 true, 1@3:1 false, 2@4:1 true"
+	if got := strings.Join(results, ", "); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestValidateStream(t *testing.T) {
+	sc := compile(t, `{"items": {"type": "integer"}}`, "draft-07")
+
+	// options apply to each value
+	var errs []int
+	err := sc.ValidateStream(jsi.NewNDJSONParser(strings.NewReader("[\"a\", \"b\"]\n[1, \"c\", \"d\"]\n")), func(r schema.StreamResult) error {
+		errs = append(errs, len(r.Errors))
+		return nil
+	}, schema.WithMaxErrors(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(errs) != "[1 1]" {
+		t.Errorf("errors of values: got %v", errs)
+	}
+
+	// errors of fn stop validating
+	stop := errors.New("stop")
+	n := 0
+	err = sc.ValidateArray(strings.NewReader(`[1, 2, 3]`), func(r schema.StreamResult) error {
+		n++
+		if r.Index == 1 {
+			return stop
+		}
+		return nil
+	})
+	if err != stop || n != 2 {
+		t.Errorf("got %v after %d values", err, n)
+	}
+}
@@ -1,9 +1,16 @@
 package jsonschema
 
+import "github.com/eachain/jsonschema/jsi"
+
 type subSchema struct {
+	key       string // index in the parent
 	path      *Pointer
-	anchor    string
+	anchors   []string
 	validator Validator
+	js        jsi.JSON // the schema compiled, set by SetSchema
+
+	resource      bool   // has an id, the root of a schema resource
+	dynamicAnchor string // since draft 2020-12
 
 	sub    map[string]*subSchema
 	parent *subSchema
@@ -20,7 +27,8 @@ func (s *subSchema) loadOrNew(index string) *subSchema {
 	m := s.sub[index]
 	if m == nil {
 		m = &subSchema{
-			path:   s.path.Object(index),
+			key:    index,
+			path:   s.path.escapedIndex(index),
 			parent: s,
 		}
 		if s.sub == nil {
@@ -34,12 +42,26 @@ func (s *subSchema) loadOrNew(index string) *subSchema {
 func (s *subSchema) setId(id *Pointer) {
 	s.path = s.path.Fix(id.clone())
 	s.path.Frag = nil
+	s.resource = true
+}
+
+// dynamicAnchors collects schemas with a dynamic anchor in the resource,
+// nested resources excluded.
+func (s *subSchema) dynamicAnchors(anchors map[string]Validator) {
+	if s.dynamicAnchor != "" && s.validator != nil {
+		anchors[s.dynamicAnchor] = s.validator
+	}
+	for _, sub := range s.sub {
+		if !sub.resource {
+			sub.dynamicAnchors(anchors)
+		}
+	}
 }
 
 /*
 func (s *subSchema) Print(prefix string) {
 	fmt.Printf("%vpath: %v\n", prefix, s.path)
-	fmt.Printf("%vanchor: %v\n", prefix, s.anchor)
+	fmt.Printf("%vanchors: %v\n", prefix, s.anchors)
 	fmt.Printf("%vvalidator: %v\n", prefix, s.validator != nil)
 	fmt.Printf("%vsub: {\n", prefix)
 	for idx, sub := range s.sub {
@@ -51,25 +73,24 @@ func (s *subSchema) Print(prefix string) {
 }
 */
 
-func (s *subSchema) visit(impl map[string]Validator, id *Pointer) {
+func (s *subSchema) visit(nodes map[string]*subSchema, id *Pointer) {
 	if s.validator != nil {
-		impl[id.String()] = s.validator
+		nodes[id.String()] = s
 	}
 	if len(s.sub) == 0 {
 		return
 	}
 
 	for idx, sub := range s.sub {
-		sub.visit(impl, id.escapedIndex(idx))
+		sub.visit(nodes, id.escapedIndex(idx))
 	}
 
-	if s.anchor != "" {
-		anchor := id.escapedIndex(s.anchor)
-		if s.validator != nil {
-			impl[anchor.String()] = s.validator
-		}
-		for idx, sub := range s.sub {
-			sub.visit(impl, anchor.escapedIndex(idx))
+	if s.validator != nil {
+		for _, name := range s.anchors {
+			// plain name fragment, relative to the base uri of the resource
+			anchor := id.Fix(s.path.clone())
+			anchor.Frag = []string{name}
+			nodes[anchor.String()] = s
 		}
 	}
 
@@ -79,10 +100,10 @@ func (s *subSchema) visit(impl map[string]Validator, id *Pointer) {
 			return
 		}
 		if s.validator != nil {
-			impl[id2.String()] = s.validator
+			nodes[id2.String()] = s
 		}
 		for idx, sub := range s.sub {
-			sub.visit(impl, id2.escapedIndex(idx))
+			sub.visit(nodes, id2.escapedIndex(idx))
 		}
 	}
 }